@@ -0,0 +1,82 @@
+package cassandra
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/gocql/gocql"
+)
+
+type translatedAddress struct {
+	ip   net.IP
+	port int
+}
+
+// newAddressTranslator builds a gocql.AddressTranslator from a map of addresses
+// advertised by the nodes to the addresses Terraform can reach them on. Keys are
+// an IP or IP:port, values an IP or host name, optionally followed by :port. Host
+// names are resolved once, when the provider is configured. Addresses missing from
+// the map are used unchanged.
+func newAddressTranslator(mapping map[string]interface{}) (gocql.AddressTranslator, error) {
+	translations := make(map[string]translatedAddress, len(mapping))
+	for from, rawTo := range mapping {
+		fromIP, fromPort, err := splitAddress(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid advertised address %q: %w", from, err)
+		}
+		if net.ParseIP(fromIP) == nil {
+			return nil, fmt.Errorf("invalid advertised address %q: not an IP address", from)
+		}
+
+		to := rawTo.(string)
+		toHost, toPort, err := splitAddress(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid translated address %q: %w", to, err)
+		}
+		toIP := net.ParseIP(toHost)
+		if toIP == nil {
+			ips, err := net.LookupIP(toHost)
+			if err != nil || len(ips) == 0 {
+				return nil, fmt.Errorf("unable to resolve translated address %q: %v", to, err)
+			}
+			toIP = ips[0]
+		}
+
+		key := net.ParseIP(fromIP).String()
+		if fromPort != 0 {
+			key = net.JoinHostPort(key, strconv.Itoa(fromPort))
+		}
+		translations[key] = translatedAddress{ip: toIP, port: toPort}
+	}
+
+	return gocql.AddressTranslatorFunc(func(addr net.IP, port int) (net.IP, int) {
+		translated, ok := translations[net.JoinHostPort(addr.String(), strconv.Itoa(port))]
+		if !ok {
+			translated, ok = translations[addr.String()]
+		}
+		if !ok {
+			return addr, port
+		}
+		if translated.port != 0 {
+			port = translated.port
+		}
+		log.Printf("[DEBUG] Translating address %s to %s:%d", addr, translated.ip, port)
+		return translated.ip, port
+	}), nil
+}
+
+// splitAddress splits an optional port off address, returning 0 when there is none.
+func splitAddress(address string) (string, int, error) {
+	host, rawPort, err := net.SplitHostPort(address)
+	if err != nil {
+		// no port, the whole string is the host (IPv6 addresses included)
+		return address, 0, nil
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil || port <= 0 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port %q", rawPort)
+	}
+	return host, port, nil
+}
@@ -0,0 +1,43 @@
+package cassandra
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAddressTranslator(t *testing.T) {
+	translator, err := newAddressTranslator(map[string]interface{}{
+		"10.0.0.1":      "192.168.1.1:19042",
+		"10.0.0.2:9142": "192.168.1.2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		ip           string
+		port         int
+		expectedIP   string
+		expectedPort int
+	}{
+		{"10.0.0.1", 9042, "192.168.1.1", 19042},
+		{"10.0.0.2", 9142, "192.168.1.2", 9142},
+		{"10.0.0.2", 9042, "10.0.0.2", 9042},
+		{"10.0.0.3", 9042, "10.0.0.3", 9042},
+	}
+	for _, c := range cases {
+		ip, port := translator.Translate(net.ParseIP(c.ip), c.port)
+		if ip.String() != c.expectedIP || port != c.expectedPort {
+			t.Errorf("Translate(%s, %d) = %s:%d, expected %s:%d", c.ip, c.port, ip, port, c.expectedIP, c.expectedPort)
+		}
+	}
+}
+
+func TestAddressTranslatorInvalid(t *testing.T) {
+	if _, err := newAddressTranslator(map[string]interface{}{"cassandra-1": "192.168.1.1"}); err == nil {
+		t.Error("expected an error for an advertised address that is not an IP")
+	}
+	if _, err := newAddressTranslator(map[string]interface{}{"10.0.0.1": "192.168.1.1:port"}); err == nil {
+		t.Error("expected an error for an invalid port")
+	}
+}
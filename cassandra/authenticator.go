@@ -0,0 +1,27 @@
+package cassandra
+
+import (
+	"github.com/gocql/gocql"
+)
+
+// plainTextAuthenticator answers the SASL PLAIN challenge for any server-side
+// authenticator class. gocql.PasswordAuthenticator refuses authenticators it does
+// not know about, which breaks clusters running LDAPAuthenticator or in-house
+// authenticators that still speak the plain-text protocol.
+type plainTextAuthenticator struct {
+	Username string
+	Password string
+}
+
+func (p plainTextAuthenticator) Challenge(req []byte) ([]byte, gocql.Authenticator, error) {
+	resp := make([]byte, 2+len(p.Username)+len(p.Password))
+	resp[0] = 0
+	copy(resp[1:], p.Username)
+	resp[len(p.Username)+1] = 0
+	copy(resp[2+len(p.Username):], p.Password)
+	return resp, nil, nil
+}
+
+func (p plainTextAuthenticator) Success(data []byte) error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// readAWSCredentials fetches the provider credentials from a Secrets Manager secret
+// or an SSM parameter, as described by the aws_credentials block.
+func readAWSCredentials(ctx context.Context, config map[string]interface{}) (*sourcedCredentials, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region := config["region"].(string); region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	if profile := config["profile"].(string); profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(profile))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %w", err)
+	}
+
+	var value string
+	if secretID := config["secret_id"].(string); secretID != "" {
+		log.Printf("Reading provider credentials from secrets manager secret %s", secretID)
+		out, err := secretsmanager.NewFromConfig(awsConfig).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secretID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read secret %s: %w", secretID, err)
+		}
+		value = aws.ToString(out.SecretString)
+	} else {
+		parameter := config["ssm_parameter"].(string)
+		log.Printf("Reading provider credentials from ssm parameter %s", parameter)
+		out, err := ssm.NewFromConfig(awsConfig).GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(parameter),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read parameter %s: %w", parameter, err)
+		}
+		value = aws.ToString(out.Parameter.Value)
+	}
+
+	return parseSecretValue(value, config["username_key"].(string), config["password_key"].(string)), nil
+}
+
+// parseSecretValue reads the credentials from a JSON object secret, a secret that
+// is not a JSON object is taken as the password itself.
+func parseSecretValue(value string, usernameKey string, passwordKey string) *sourcedCredentials {
+	var secret map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &secret); err != nil {
+		return &sourcedCredentials{Password: value}
+	}
+
+	credentials := &sourcedCredentials{}
+	if username, ok := secret[usernameKey].(string); ok {
+		credentials.Username = username
+	}
+	if password, ok := secret[passwordKey].(string); ok {
+		credentials.Password = password
+	}
+	return credentials
+}
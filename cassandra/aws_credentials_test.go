@@ -0,0 +1,17 @@
+package cassandra
+
+import (
+	"testing"
+)
+
+func TestParseSecretValue(t *testing.T) {
+	credentials := parseSecretValue(`{"username": "admin", "password": "secret", "engine": "cassandra"}`, "username", "password")
+	if credentials.Username != "admin" || credentials.Password != "secret" {
+		t.Fatalf("unexpected credentials from JSON secret: %+v", credentials)
+	}
+
+	credentials = parseSecretValue("plain-secret", "username", "password")
+	if credentials.Username != "" || credentials.Password != "plain-secret" {
+		t.Fatalf("unexpected credentials from plain secret: %+v", credentials)
+	}
+}
@@ -0,0 +1,229 @@
+package cassandra
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	cqlOpcodeError     = 0x00
+	cqlOpcodeOptions   = 0x05
+	cqlOpcodeSupported = 0x06
+
+	cqlDefaultOptionsProtoVersion = 4
+	cqlMaxFrameLength             = 256 * 1024 * 1024
+)
+
+// applyNegotiatedCQLVersion replaces the CQL version gocql sends on startup with the
+// highest one supported by the cluster. gocql sends its configured CQLVersion
+// verbatim and falls back to 3.0.0, so the version is read from the SUPPORTED
+// response of the first reachable host group before any session is opened. The
+// negotiation is retried on the next connection until it succeeds.
+func (pc *ProviderConfig) applyNegotiatedCQLVersion() {
+	pc.cqlVersionMu.Lock()
+	defer pc.cqlVersionMu.Unlock()
+	if pc.cqlVersionNegotiated {
+		return
+	}
+
+	clusters := append([]*gocql.ClusterConfig{pc.Cluster}, pc.FallbackClusters...)
+	var version string
+	var err error
+	for _, cluster := range clusters {
+		ctx, cancel := context.WithTimeout(context.Background(), cluster.ConnectTimeout)
+		version, err = negotiateCQLVersion(ctx, cluster)
+		cancel()
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		log.Printf("[WARN] Unable to negotiate the CQL version, using %s: %s", pc.Cluster.CQLVersion, err)
+		return
+	}
+
+	log.Printf("Negotiated CQL version %s", version)
+	for _, cluster := range clusters {
+		cluster.CQLVersion = version
+	}
+	pc.cqlVersionNegotiated = true
+}
+
+// negotiateCQLVersion sends an OPTIONS request to the hosts of cluster in order and
+// returns the highest CQL version listed by the first one that answers.
+func negotiateCQLVersion(ctx context.Context, cluster *gocql.ClusterConfig) (string, error) {
+	if len(cluster.Hosts) == 0 {
+		return "", fmt.Errorf("no hosts configured")
+	}
+	var err error
+	for _, host := range cluster.Hosts {
+		var supported map[string][]string
+		supported, err = requestSupportedOptions(ctx, cluster, host)
+		if err != nil {
+			continue
+		}
+		versions := supported["CQL_VERSION"]
+		if len(versions) == 0 {
+			return "", fmt.Errorf("%s did not list any CQL version", host)
+		}
+		highest := versions[0]
+		for _, version := range versions[1:] {
+			if compareCQLVersions(version, highest) > 0 {
+				highest = version
+			}
+		}
+		return highest, nil
+	}
+	return "", err
+}
+
+// requestSupportedOptions opens a connection to host the way gocql does, through
+// the configured dialer and TLS settings, and returns the options listed in the
+// SUPPORTED response to an OPTIONS request. OPTIONS is answered before
+// authentication so no credentials are sent.
+func requestSupportedOptions(ctx context.Context, cluster *gocql.ClusterConfig, host string) (map[string][]string, error) {
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(host, strconv.Itoa(cluster.Port))
+	}
+
+	var dialer gocql.Dialer = &net.Dialer{Timeout: cluster.ConnectTimeout}
+	if cluster.Dialer != nil {
+		dialer = cluster.Dialer
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if cluster.SslOpts != nil && cluster.SslOpts.Config != nil {
+		tlsConfig := cluster.SslOpts.Config.Clone()
+		if !tlsConfig.InsecureSkipVerify && tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(address)
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	protoVersion := byte(cluster.ProtoVersion)
+	if protoVersion == 0 {
+		protoVersion = cqlDefaultOptionsProtoVersion
+	}
+	// frame headers carry a one byte stream id before protocol version 3
+	headerLength := 9
+	if protoVersion < 3 {
+		headerLength = 8
+	}
+
+	request := make([]byte, headerLength)
+	request[0] = protoVersion
+	request[headerLength-5] = cqlOpcodeOptions
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerLength)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	opcode := header[headerLength-5]
+	length := binary.BigEndian.Uint32(header[headerLength-4:])
+	if length > cqlMaxFrameLength {
+		return nil, fmt.Errorf("%s answered with a frame of %d bytes", address, length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(conn, body); err != nil {
+		return nil, err
+	}
+
+	switch opcode {
+	case cqlOpcodeSupported:
+		return parseStringMultimap(body)
+	case cqlOpcodeError:
+		if len(body) >= 6 {
+			message, _, err := readCQLString(body[4:])
+			if err == nil {
+				return nil, fmt.Errorf("%s rejected OPTIONS: %s", address, message)
+			}
+		}
+		return nil, fmt.Errorf("%s rejected OPTIONS", address)
+	}
+	return nil, fmt.Errorf("%s answered OPTIONS with unexpected opcode 0x%02x", address, opcode)
+}
+
+// parseStringMultimap decodes a [string multimap] as defined by the native protocol.
+func parseStringMultimap(body []byte) (map[string][]string, error) {
+	if len(body) < 2 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	entries := int(binary.BigEndian.Uint16(body))
+	body = body[2:]
+	multimap := make(map[string][]string, entries)
+	for i := 0; i < entries; i++ {
+		key, rest, err := readCQLString(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 2 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		values := make([]string, int(binary.BigEndian.Uint16(rest)))
+		rest = rest[2:]
+		for j := range values {
+			if values[j], rest, err = readCQLString(rest); err != nil {
+				return nil, err
+			}
+		}
+		multimap[key] = values
+		body = rest
+	}
+	return multimap, nil
+}
+
+// readCQLString decodes a [string], a length prefixed by a short, and returns the
+// remaining bytes.
+func readCQLString(body []byte) (string, []byte, error) {
+	if len(body) < 2 {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	length := int(binary.BigEndian.Uint16(body))
+	if len(body) < 2+length {
+		return "", nil, io.ErrUnexpectedEOF
+	}
+	return string(body[2 : 2+length]), body[2+length:], nil
+}
+
+// compareCQLVersions compares two dotted CQL versions numerically, returning a
+// negative number, zero or a positive number.
+func compareCQLVersions(a string, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart != bPart {
+			return aPart - bPart
+		}
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestNegotiateCQLVersion(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		request := make([]byte, 9)
+		if _, err := io.ReadFull(conn, request); err != nil || request[4] != cqlOpcodeOptions {
+			return
+		}
+
+		body := []byte{0, 1}
+		body = appendCQLString(body, "CQL_VERSION")
+		body = append(body, 0, 3)
+		for _, version := range []string{"3.4.5", "3.10.0", "3.4.7"} {
+			body = appendCQLString(body, version)
+		}
+		response := []byte{0x84, 0, 0, 0, cqlOpcodeSupported, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(response[5:], uint32(len(body)))
+		conn.Write(append(response, body...))
+	}()
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed.Close()
+
+	// the first host refuses the connection, the second one answers
+	cluster := gocql.NewCluster(closed.Addr().String(), listener.Addr().String())
+	cluster.ConnectTimeout = time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	version, err := negotiateCQLVersion(ctx, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "3.10.0" {
+		t.Fatalf("expected 3.10.0, got %s", version)
+	}
+}
+
+func appendCQLString(body []byte, s string) []byte {
+	body = binary.BigEndian.AppendUint16(body, uint16(len(s)))
+	return append(body, s...)
+}
@@ -0,0 +1,152 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCassandraClients() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the clients connected to the node the provider is connected to from the system_views.clients virtual table, Cassandra 4.0 and later. The connections of the provider are included",
+		ReadContext: dataSourceClientsRead,
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the clients authenticated as this role",
+			},
+			"clients": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Connected clients, ordered by address and port",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address of the client",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Port of the client",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hostname of the client",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Role the client authenticated as",
+						},
+						"connection_stage": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Stage of the connection, e.g. ready",
+						},
+						"driver_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the driver, when reported by the client",
+						},
+						"driver_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of the driver, when reported by the client",
+						},
+						"protocol_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Native protocol version of the connection",
+						},
+						"request_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of requests sent over the connection",
+						},
+						"ssl_enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the connection is encrypted",
+						},
+					},
+				},
+			},
+			"client_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of returned clients",
+			},
+		},
+	}
+}
+
+func dataSourceClientsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	username := d.Get("username").(string)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	if supported, ok := releaseVersionAtLeast(providerConfig.ReleaseVersion, 4, 0); ok && !supported {
+		return diag.Errorf("system_views.clients requires Cassandra 4.0 or later, the cluster runs %s", providerConfig.ReleaseVersion)
+	}
+
+	// the columns of system_views.clients vary between releases
+	query := `SELECT * FROM system_views.clients`
+	iter := session.Query(query).WithContext(ctx).Iter()
+	rows, err := iter.SliceMap()
+	if err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	if err := iter.Close(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	clients := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		client := map[string]interface{}{
+			"address":          "",
+			"port":             row["port"],
+			"hostname":         row["hostname"],
+			"username":         row["username"],
+			"connection_stage": row["connection_stage"],
+			"driver_name":      row["driver_name"],
+			"driver_version":   row["driver_version"],
+			"protocol_version": row["protocol_version"],
+			"request_count":    row["request_count"],
+			"ssl_enabled":      row["ssl_enabled"],
+		}
+		if address, ok := row["address"].(net.IP); ok {
+			client["address"] = address.String()
+		}
+		if username != "" && client["username"] != username {
+			continue
+		}
+		clients = append(clients, client)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return fmt.Sprint(clients[i]["address"], clients[i]["port"]) < fmt.Sprint(clients[j]["address"], clients[j]["port"])
+	})
+
+	flattened := make([]interface{}, 0, len(clients))
+	for _, client := range clients {
+		flattened = append(flattened, client)
+	}
+
+	d.SetId(hash("clients|" + username))
+	d.Set("clients", flattened)
+	d.Set("client_count", len(clients))
+	return diags
+}
@@ -0,0 +1,28 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraClientsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraClientsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cassandra_clients.app", "client_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraClientsDataSourceConfig = `
+data "cassandra_clients" "app" {
+  username = "app-without-connections"
+}
+`
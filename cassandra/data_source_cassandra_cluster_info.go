@@ -0,0 +1,99 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCassandraClusterInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the name, version and flavor of the cluster, e.g. to only use features of newer releases",
+		ReadContext: dataSourceClusterInfoRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the cluster",
+			},
+			"release_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cassandra release version of the node the provider is connected to, e.g. 5.0.2",
+			},
+			"partitioner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Partitioner of the cluster, e.g. org.apache.cassandra.dht.Murmur3Partitioner",
+			},
+			"cql_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Highest CQL version supported",
+			},
+			"native_protocol_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Highest native protocol version supported",
+			},
+			"scylla": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster runs Scylla",
+			},
+			"dse": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster runs DataStax Enterprise",
+			},
+			"dse_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DataStax Enterprise version, empty for other clusters",
+			},
+			"amazon_keyspaces": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster is Amazon Keyspaces",
+			},
+		},
+	}
+}
+
+func dataSourceClusterInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	// the columns of system.local vary between releases and flavors
+	query := `SELECT * FROM system.local WHERE key = 'local'`
+	local := make(map[string]interface{})
+	if err := session.Query(query).WithContext(ctx).MapScan(local); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	column := func(name string) string {
+		if value, ok := local[name]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+		return ""
+	}
+
+	d.SetId(column("cluster_name"))
+	d.Set("cluster_name", column("cluster_name"))
+	d.Set("release_version", column("release_version"))
+	d.Set("partitioner", column("partitioner"))
+	d.Set("cql_version", column("cql_version"))
+	d.Set("native_protocol_version", column("native_protocol_version"))
+	d.Set("scylla", providerConfig.Mode == modeScylla)
+	d.Set("dse", column("dse_version") != "")
+	d.Set("dse_version", column("dse_version"))
+	d.Set("amazon_keyspaces", providerConfig.AmazonKeyspaces)
+	return diags
+}
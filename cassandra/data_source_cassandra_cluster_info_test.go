@@ -0,0 +1,29 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraClusterInfoDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraClusterInfoDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.cassandra_cluster_info.cluster", "cluster_name"),
+					resource.TestCheckResourceAttrSet("data.cassandra_cluster_info.cluster", "release_version"),
+					resource.TestCheckResourceAttr("data.cassandra_cluster_info.cluster", "partitioner", "org.apache.cassandra.dht.Murmur3Partitioner"),
+					resource.TestCheckResourceAttr("data.cassandra_cluster_info.cluster", "amazon_keyspaces", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraClusterInfoDataSourceConfig = `
+data "cassandra_cluster_info" "cluster" {}
+`
@@ -0,0 +1,73 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCassandraKeyspace() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the replication settings of an existing keyspace",
+		ReadContext: dataSourceKeyspaceRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of keyspace, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"replication_strategy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Keyspace replication strategy",
+			},
+			"strategy_options": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "strategy options of the replication strategy, e.g. the replication factor of each datacenter",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"durable_writes": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether durable writes are enabled",
+			},
+		},
+	}
+}
+
+func dataSourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	keyspaceMetadata, err := session.KeyspaceMetadata(unquoteIdentifier(name))
+	if err == gocql.ErrKeyspaceDoesNotExist {
+		return errorDiagnostics(fmt.Errorf("keyspace %s: %w", name, err), "", cty.GetAttrPath("name"))
+	} else if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+
+	strategyOptions := make(map[string]string)
+	for key, value := range keyspaceMetadata.StrategyOptions {
+		strategyOptions[key] = fmt.Sprint(value)
+	}
+
+	d.SetId(name)
+	d.Set("replication_strategy", shortStrategyClass(keyspaceMetadata.StrategyClass))
+	d.Set("strategy_options", strategyOptions)
+	d.Set("durable_writes", keyspaceMetadata.DurableWrites)
+	return diags
+}
@@ -0,0 +1,38 @@
+package cassandra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraKeyspaceDataSource_basic(t *testing.T) {
+	keyspace := "some_keyspace"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraKeyspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraKeyspaceDataSourceConfig(keyspace),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cassandra_keyspace.keyspace", "replication_strategy", "SimpleStrategy"),
+					resource.TestCheckResourceAttr("data.cassandra_keyspace.keyspace", "strategy_options.replication_factor", "1"),
+					resource.TestCheckResourceAttr("data.cassandra_keyspace.keyspace", "durable_writes", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCassandraKeyspaceDataSourceConfig(keyspace string) string {
+	return fmt.Sprintf(`
+%s
+
+data "cassandra_keyspace" "keyspace" {
+    name = cassandra_keyspace.keyspace.name
+}
+`, testAccCassandraKeyspaceConfigBasic(keyspace))
+}
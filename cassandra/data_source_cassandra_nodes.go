@@ -0,0 +1,169 @@
+package cassandra
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nodeDialTimeout bounds the connection attempt deciding whether a node is up.
+const nodeDialTimeout = 5 * time.Second
+
+func dataSourceCassandraNodes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the nodes of the cluster with their location, version and status",
+		ReadContext: dataSourceNodesRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the nodes of this datacenter",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes of the cluster, ordered by datacenter, rack and address",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address clients connect to",
+						},
+						"host_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Host ID of the node",
+						},
+						"datacenter": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Datacenter of the node",
+						},
+						"rack": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Rack of the node",
+						},
+						"release_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cassandra release version of the node",
+						},
+						"up": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the node accepts connections on the CQL port",
+						},
+					},
+				},
+			},
+			"all_up": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every returned node is up",
+			},
+		},
+	}
+}
+
+// clusterNode is a node as described by system.local or system.peers.
+type clusterNode struct {
+	address        net.IP
+	hostID         gocql.UUID
+	datacenter     string
+	rack           string
+	releaseVersion string
+	up             bool
+}
+
+// readClusterNodes returns the node answering the query followed by its peers.
+func readClusterNodes(ctx context.Context, session *gocql.Session) ([]clusterNode, error) {
+	var local clusterNode
+	if err := session.Query(`SELECT rpc_address, host_id, data_center, rack, release_version FROM system.local WHERE key = 'local'`).WithContext(ctx).
+		Scan(&local.address, &local.hostID, &local.datacenter, &local.rack, &local.releaseVersion); err != nil {
+		return nil, err
+	}
+	// the node answered, it is up
+	local.up = true
+	nodes := []clusterNode{local}
+
+	iter := session.Query(`SELECT rpc_address, host_id, data_center, rack, release_version FROM system.peers`).WithContext(ctx).Iter()
+	var peer clusterNode
+	for iter.Scan(&peer.address, &peer.hostID, &peer.datacenter, &peer.rack, &peer.releaseVersion) {
+		nodes = append(nodes, peer)
+		peer = clusterNode{}
+	}
+	return nodes, iter.Close()
+}
+
+// nodeIsUp reports whether address accepts connections on port.
+func nodeIsUp(ctx context.Context, address net.IP, port int) bool {
+	dialer := net.Dialer{Timeout: nodeDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address.String(), strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func dataSourceNodesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	datacenter := d.Get("datacenter").(string)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	nodes, err := readClusterNodes(ctx, session)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].datacenter != nodes[j].datacenter {
+			return nodes[i].datacenter < nodes[j].datacenter
+		}
+		if nodes[i].rack != nodes[j].rack {
+			return nodes[i].rack < nodes[j].rack
+		}
+		return nodes[i].address.String() < nodes[j].address.String()
+	})
+
+	allUp := true
+	addresses := make([]string, 0, len(nodes))
+	flattened := make([]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		if datacenter != "" && node.datacenter != datacenter {
+			continue
+		}
+		if !node.up {
+			node.up = nodeIsUp(ctx, node.address, providerConfig.Cluster.Port)
+		}
+		allUp = allUp && node.up
+		addresses = append(addresses, node.address.String())
+		flattened = append(flattened, map[string]interface{}{
+			"address":         node.address.String(),
+			"host_id":         node.hostID.String(),
+			"datacenter":      node.datacenter,
+			"rack":            node.rack,
+			"release_version": node.releaseVersion,
+			"up":              node.up,
+		})
+	}
+
+	d.SetId(hash(datacenter + "|" + strings.Join(addresses, ",")))
+	d.Set("nodes", flattened)
+	d.Set("all_up", allUp)
+	return diags
+}
@@ -0,0 +1,31 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraNodesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraNodesDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cassandra_nodes.nodes", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.cassandra_nodes.nodes", "nodes.0.datacenter", "datacenter1"),
+					resource.TestCheckResourceAttr("data.cassandra_nodes.nodes", "nodes.0.up", "true"),
+					resource.TestCheckResourceAttr("data.cassandra_nodes.nodes", "all_up", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraNodesDataSourceConfig = `
+data "cassandra_nodes" "nodes" {
+  datacenter = "datacenter1"
+}
+`
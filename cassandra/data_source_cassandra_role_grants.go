@@ -0,0 +1,189 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceCassandraRoleGrants() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the permissions of a role as listed by LIST ALL PERMISSIONS, e.g. for compliance reports",
+		ReadContext: dataSourceRoleGrantsRead,
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the role",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"inherited": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Include the permissions the role inherits from the roles granted to it",
+			},
+			"permissions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Permissions of the role",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Role the permission is granted to, another role for inherited permissions",
+						},
+						"privilege": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Privilege as used by cassandra_grant, e.g. select",
+						},
+						"resource": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Resource as listed by the server, e.g. <table shop.users>",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Resource type as used by cassandra_grant, e.g. table",
+						},
+						"keyspace_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Keyspace of the resource, empty for resources outside of keyspaces",
+						},
+						"identifier": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the table, function, role or mbean of the resource",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// permissionResourceTypes are the resource types of cassandra_grant as listed by
+// LIST PERMISSIONS, ordered so that e.g. "all roles" is tried before "role". The
+// keyspace scoped function wildcard is listed differently and parsed separately.
+var permissionResourceTypes = []string{resourceAllFunctions, resourceAllKeyspaces, resourceAllRoles, resourceAllMbeans, resourceKeyspace, resourceTable, resourceFunction, resourceRoles, resourceRole, resourceMbeans, resourceMbean}
+
+// permissionResource is a resource as listed by LIST PERMISSIONS.
+type permissionResource struct {
+	ResourceType string
+	Keyspace     string
+	Identifier   string
+}
+
+// allFunctionsInKeyspacePrefix starts the keyspace scoped function wildcard as listed
+// by LIST PERMISSIONS, e.g. <all functions in shop>, which drops the keyword keyspace.
+const allFunctionsInKeyspacePrefix = "all functions in "
+
+// String renders the resource as listed by LIST PERMISSIONS.
+func (r permissionResource) String() string {
+	if r.ResourceType == resourceAllFunctionsInKeyspace {
+		return fmt.Sprintf("<%s%s>", allFunctionsInKeyspacePrefix, r.Keyspace)
+	}
+	name := r.Keyspace
+	if r.Keyspace != "" && r.Identifier != "" {
+		name += "."
+	}
+	name += r.Identifier
+	if name == "" {
+		return fmt.Sprintf("<%s>", r.ResourceType)
+	}
+	return fmt.Sprintf("<%s %s>", r.ResourceType, name)
+}
+
+// parsePermissionResource parses resources listed by LIST PERMISSIONS, e.g.
+// <table shop.users> or <all functions in shop>.
+func parsePermissionResource(resource string) (permissionResource, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(resource, "<"), ">")
+	if strings.HasPrefix(strings.ToLower(trimmed), allFunctionsInKeyspacePrefix) {
+		return permissionResource{ResourceType: resourceAllFunctionsInKeyspace, Keyspace: strings.TrimSpace(trimmed[len(allFunctionsInKeyspacePrefix):])}, nil
+	}
+	for _, resourceType := range permissionResourceTypes {
+		if !strings.EqualFold(trimmed, resourceType) && !strings.HasPrefix(strings.ToLower(trimmed), resourceType+" ") {
+			continue
+		}
+		name := strings.TrimSpace(trimmed[len(resourceType):])
+		parsed := permissionResource{ResourceType: resourceType}
+		switch resourceType {
+		case resourceKeyspace:
+			parsed.Keyspace = name
+		case resourceTable, resourceFunction:
+			keyspaceName, identifier, ok := strings.Cut(name, ".")
+			if !ok {
+				return permissionResource{}, fmt.Errorf("unexpected %s resource %s", resourceType, resource)
+			}
+			parsed.Keyspace, parsed.Identifier = keyspaceName, identifier
+		default:
+			parsed.Identifier = name
+		}
+		return parsed, nil
+	}
+	return permissionResource{}, fmt.Errorf("unknown resource %s", resource)
+}
+
+// listPermissions returns the rows of LIST ALL PERMISSIONS OF role.
+func listPermissions(ctx context.Context, session *gocql.Session, role string, inherited bool) ([]map[string]interface{}, error) {
+	query := fmt.Sprintf(`LIST ALL PERMISSIONS OF %s`, quoteLiteral(role))
+	if !inherited {
+		query += " NORECURSIVE"
+	}
+	iter := session.Query(query).WithContext(ctx).Iter()
+	rows, err := iter.SliceMap()
+	if err != nil {
+		return nil, err
+	}
+	return rows, iter.Close()
+}
+
+func dataSourceRoleGrantsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Get("role").(string)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	rows, err := listPermissions(ctx, session, role, d.Get("inherited").(bool))
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("role"))
+	}
+
+	permissions := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		resource, _ := row["resource"].(string)
+		parsed, err := parsePermissionResource(resource)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		grantedTo, _ := row["role"].(string)
+		permission, _ := row["permission"].(string)
+		permissions = append(permissions, map[string]interface{}{
+			"role":          grantedTo,
+			"privilege":     strings.ToLower(permission),
+			"resource":      resource,
+			"resource_type": parsed.ResourceType,
+			"keyspace_name": parsed.Keyspace,
+			"identifier":    parsed.Identifier,
+		})
+	}
+
+	d.SetId(role)
+	d.Set("permissions", permissions)
+	return diags
+}
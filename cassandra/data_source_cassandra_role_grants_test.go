@@ -0,0 +1,69 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestParsePermissionResource(t *testing.T) {
+	for raw, expected := range map[string]permissionResource{
+		"<all keyspaces>":                   {ResourceType: "all keyspaces"},
+		"<keyspace shop>":                   {ResourceType: "keyspace", Keyspace: "shop"},
+		"<table shop.users>":                {ResourceType: "table", Keyspace: "shop", Identifier: "users"},
+		"<all functions in shop>":           {ResourceType: "all functions in keyspace", Keyspace: "shop"},
+		"<all functions>":                   {ResourceType: "all functions"},
+		"<function shop.total(int, int)>":   {ResourceType: "function", Keyspace: "shop", Identifier: "total(int, int)"},
+		"<role app>":                        {ResourceType: "role", Identifier: "app"},
+		"<all roles>":                       {ResourceType: "all roles"},
+		"<mbean org.apache.cassandra.db:*>": {ResourceType: "mbean", Identifier: "org.apache.cassandra.db:*"},
+	} {
+		parsed, err := parsePermissionResource(raw)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", raw, err)
+		} else if parsed != expected {
+			t.Errorf("expected %+v for %s, got %+v", expected, raw, parsed)
+		}
+		if expected.String() != raw {
+			t.Errorf("expected %+v to render as %s, got %s", expected, raw, expected)
+		}
+	}
+
+	if _, err := parsePermissionResource("<data>"); err == nil {
+		t.Error("expected an error for an unknown resource")
+	}
+}
+
+func TestAccCassandraRoleGrantsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleGrantsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cassandra_role_grants.app", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.cassandra_role_grants.app", "permissions.0.privilege", "select"),
+					resource.TestCheckResourceAttr("data.cassandra_role_grants.app", "permissions.0.resource_type", "all keyspaces"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraRoleGrantsDataSourceConfig = `
+resource "cassandra_role" "app" {
+  name     = "app"
+  password = "app-password-with-at-least-forty-characters!"
+}
+
+resource "cassandra_grant" "app" {
+  privilege     = "select"
+  grantee       = cassandra_role.app.name
+  resource_type = "all keyspaces"
+}
+
+data "cassandra_role_grants" "app" {
+  role = cassandra_grant.app.grantee
+}
+`
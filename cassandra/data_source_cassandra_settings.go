@@ -0,0 +1,81 @@
+package cassandra
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCassandraSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read the configuration of the node the provider is connected to from the system_views.settings virtual table, Cassandra 4.0 and later",
+		ReadContext: dataSourceSettingsRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return these settings, e.g. authenticator. All settings are returned when not set",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Values of the settings by name, unset settings have an empty value",
+			},
+		},
+	}
+}
+
+func dataSourceSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	names := setToArray(d.Get("names"))
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	if supported, ok := releaseVersionAtLeast(providerConfig.ReleaseVersion, 4, 0); ok && !supported {
+		return diag.Errorf("system_views.settings requires Cassandra 4.0 or later, the cluster runs %s", providerConfig.ReleaseVersion)
+	}
+
+	query := `SELECT name, value FROM system_views.settings`
+	settings := make(map[string]string)
+	iter := session.Query(query).WithContext(ctx).Iter()
+	var (
+		name  string
+		value *string
+	)
+	for iter.Scan(&name, &value) {
+		settings[name] = ""
+		if value != nil {
+			settings[name] = *value
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	if len(names) > 0 {
+		selected := make(map[string]string, len(names))
+		for _, name := range names {
+			value, ok := settings[name]
+			if !ok {
+				return diag.Errorf("unknown setting %s", name)
+			}
+			selected[name] = value
+		}
+		settings = selected
+	}
+
+	sort.Strings(names)
+	d.SetId(hash(strings.Join(names, ",")))
+	d.Set("settings", settings)
+	return diags
+}
@@ -0,0 +1,30 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraSettingsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraSettingsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cassandra_settings.settings", "settings.%", "2"),
+					resource.TestCheckResourceAttrSet("data.cassandra_settings.settings", "settings.authenticator"),
+					resource.TestCheckResourceAttrSet("data.cassandra_settings.settings", "settings.cluster_name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraSettingsDataSourceConfig = `
+data "cassandra_settings" "settings" {
+  names = ["authenticator", "cluster_name"]
+}
+`
@@ -0,0 +1,86 @@
+package cassandra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// errorDiagnostics translates an error returned by the driver into a diagnostic
+// with an actionable summary and remediation hint. statement is the CQL that
+// failed, if any, and is included (redacted) for statement level errors. path
+// points at the attribute the error relates to and may be nil.
+func errorDiagnostics(err error, statement string, path cty.Path) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	summary, hint := classifyError(err)
+	detail := err.Error()
+	if statement != "" {
+		detail = fmt.Sprintf("%s\n\nStatement: %s", detail, redactStatement(statement))
+	}
+	if hint != "" {
+		detail = fmt.Sprintf("%s\n\n%s", detail, hint)
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        detail,
+			AttributePath: path,
+		},
+	}
+}
+
+// classifyError returns a summary and a remediation hint for well known failures.
+func classifyError(err error) (string, string) {
+	var requestErr gocql.RequestError
+	if errors.As(err, &requestErr) {
+		switch requestErr.Code() {
+		case gocql.ErrCodeCredentials:
+			return "Authentication failed", "Check the provider username and password, or the secret they are read from."
+		case gocql.ErrCodeUnauthorized:
+			return "Unauthorized", "The role used by the provider lacks the permission required for this statement. Grant it, or configure a role with sufficient privileges."
+		case gocql.ErrCodeSyntax:
+			return "Invalid CQL syntax", "The statement is not valid for this server version, check the resource arguments it was generated from."
+		case gocql.ErrCodeInvalid, gocql.ErrCodeConfig:
+			return "Invalid request", "The server rejected the statement, check the resource arguments it was generated from."
+		case gocql.ErrCodeAlreadyExists:
+			return "Already exists", "Import the existing object into the state, or set adopt_existing on the provider to take it over."
+		case gocql.ErrCodeUnavailable, gocql.ErrCodeReadTimeout, gocql.ErrCodeWriteTimeout:
+			return "Not enough replicas available", "Check the cluster health, or lower the consistency level used by the provider or the resource."
+		}
+	}
+
+	if errors.Is(err, gocql.ErrKeyspaceDoesNotExist) {
+		return "Keyspace does not exist", "Create the keyspace first, or reference it from a cassandra_keyspace resource so it is created before."
+	}
+	if errors.Is(err, gocql.ErrNoConnections) || errors.Is(err, gocql.ErrNoConnectionsStarted) || errors.Is(err, gocql.ErrNoHosts) {
+		return "No hosts available", "Check that the configured hosts and port are reachable from where Terraform runs."
+	}
+
+	// errors raised while creating a session are flattened to strings by the driver
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "authentication required"):
+		return "Authentication required", "The cluster requires authentication, set the provider username and password."
+	case strings.Contains(message, "username") && strings.Contains(message, "password"),
+		strings.Contains(message, "authentication failed"), strings.Contains(message, "bad credentials"):
+		return "Authentication failed", "Check the provider username and password, or the secret they are read from."
+	case strings.Contains(message, "unsupported protocol"):
+		return "Unsupported protocol version", "Set protocol_version to a version supported by the server, or to 0 to negotiate it."
+	case strings.Contains(message, "no hosts available"), strings.Contains(message, "unable to connect to initial hosts"),
+		strings.Contains(message, "no connections were made"):
+		return "No hosts available", "Check that the configured hosts and port are reachable from where Terraform runs."
+	case strings.Contains(message, "x509:"), strings.Contains(message, "tls:"):
+		return "TLS handshake failed", "Check use_ssl, root_ca and the client certificate settings against the server configuration."
+	}
+
+	return "Cassandra request failed", ""
+}
@@ -0,0 +1,33 @@
+package cassandra
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestClassifyError(t *testing.T) {
+	cases := map[string]error{
+		"Keyspace does not exist":  gocql.ErrKeyspaceDoesNotExist,
+		"No hosts available":       errors.New("gocql: unable to create session: control: unable to connect to initial hosts: dial tcp 127.0.0.1:9042: connect: connection refused"),
+		"Authentication failed":    errors.New("gocql: unable to create session: unable to discover protocol version: Provided username cassandra and/or password are incorrect"),
+		"Cassandra request failed": errors.New("boom"),
+	}
+	for expected, err := range cases {
+		if summary, _ := classifyError(err); summary != expected {
+			t.Errorf("classifyError(%q) = %q, expected %q", err, summary, expected)
+		}
+	}
+}
+
+func TestErrorDiagnosticsRedactsStatement(t *testing.T) {
+	diags := errorDiagnostics(errors.New("boom"), `ALTER ROLE 'app' WITH PASSWORD = 'secret'`, nil)
+	if len(diags) != 1 {
+		t.Fatalf("expected a single diagnostic, got %d", len(diags))
+	}
+	if strings.Contains(diags[0].Detail, "secret") {
+		t.Errorf("expected the password to be redacted, got %q", diags[0].Detail)
+	}
+}
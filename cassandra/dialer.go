@@ -0,0 +1,121 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/net/proxy"
+)
+
+// sshTunnelDialer routes connections through an SSH bastion. The SSH connection is
+// established lazily on first use so configuring the provider does not require
+// the bastion to be reachable.
+type sshTunnelDialer struct {
+	address string
+	config  *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+func newSSHTunnelDialer(host string, port int, user string, privateKey string, password string, hostKey string, timeout time.Duration) (*sshTunnelDialer, error) {
+	var authMethods []ssh.AuthMethod
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ssh private key: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if password != "" {
+		authMethods = append(authMethods, ssh.Password(password))
+	}
+	if len(authMethods) == 0 {
+		return nil, fmt.Errorf("ssh tunnel requires a private key or a password")
+	}
+
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if hostKey != "" {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ssh host key: %w", err)
+		}
+		hostKeyCallback = ssh.FixedHostKey(publicKey)
+	} else {
+		log.Printf("[WARN] ssh_tunnel.host_key is not set, the bastion host key will not be verified")
+	}
+
+	return &sshTunnelDialer{
+		address: net.JoinHostPort(host, strconv.Itoa(port)),
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            authMethods,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         timeout,
+		},
+	}, nil
+}
+
+func (s *sshTunnelDialer) sshClient() (*ssh.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil {
+		// a failing keepalive means the bastion connection is gone, reconnect
+		if _, _, err := s.client.SendRequest("keepalive@openssh.com", true, nil); err == nil {
+			return s.client, nil
+		}
+		s.client.Close()
+		s.client = nil
+	}
+
+	log.Printf("Opening ssh tunnel through %s", s.address)
+	client, err := ssh.Dial("tcp", s.address, s.config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to ssh bastion %s: %w", s.address, err)
+	}
+	s.client = client
+	return client, nil
+}
+
+func (s *sshTunnelDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := s.sshClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.DialContext(ctx, network, addr)
+}
+
+// socks5Dialer routes connections through a SOCKS5 proxy.
+type socks5Dialer struct {
+	dialer proxy.ContextDialer
+}
+
+func newSOCKS5Dialer(address string, username string, password string, timeout time.Duration, keepalive time.Duration) (*socks5Dialer, error) {
+	var auth *proxy.Auth
+	if username != "" {
+		auth = &proxy.Auth{
+			User:     username,
+			Password: password,
+		}
+	}
+	dialer, err := proxy.SOCKS5("tcp", address, auth, &net.Dialer{Timeout: timeout, KeepAlive: keepalive})
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure socks5 proxy %s: %w", address, err)
+	}
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("socks5 proxy %s does not support dialing with context", address)
+	}
+	return &socks5Dialer{dialer: contextDialer}, nil
+}
+
+func (s *socks5Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	return s.dialer.DialContext(ctx, network, addr)
+}
@@ -0,0 +1,86 @@
+package cassandra
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	modeAuto      = "auto"
+	modeCassandra = "cassandra"
+	modeScylla    = "scylla"
+
+	pwEncryptionBcrypt = "bcrypt"
+	pwEncryptionSHA512 = "sha-512"
+)
+
+// detectServerFlavor resolves the settings left to auto detection from the server
+// behind session. Scylla is recognised by its system.versions table, newer Scylla
+// releases keep roles in the system keyspace instead of system_auth. Amazon
+// Keyspaces is recognised by its system_schema_mcs keyspace.
+func (pc *ProviderConfig) detectServerFlavor(session *gocql.Session) {
+	if err := session.Query(`SELECT release_version FROM system.local WHERE key = 'local'`).Scan(&pc.ReleaseVersion); err != nil {
+		log.Printf("[WARN] Unable to read release_version from system.local: %s", err)
+	}
+
+	if pc.Mode == modeAuto {
+		pc.Mode = modeCassandra
+		var scyllaVersion string
+		if err := session.Query(`SELECT version FROM system.versions WHERE key = 'local'`).Scan(&scyllaVersion); err == nil {
+			log.Printf("Detected Scylla %s", scyllaVersion)
+			pc.Mode = modeScylla
+		}
+	}
+
+	if _, err := session.KeyspaceMetadata("system_schema_mcs"); err == nil {
+		log.Printf("Detected Amazon Keyspaces")
+		pc.AmazonKeyspaces = true
+	}
+
+	if pc.SystemKeyspaceName == "" {
+		pc.SystemKeyspaceName = "system_auth"
+		if pc.Mode == modeScylla {
+			keyspaceMetadata, err := session.KeyspaceMetadata("system")
+			if err == nil {
+				if _, ok := keyspaceMetadata.Tables["roles"]; ok {
+					pc.SystemKeyspaceName = "system"
+				}
+			}
+		}
+	}
+
+	if pc.PwEncryptionAlgorithm == "" {
+		pc.PwEncryptionAlgorithm = pwEncryptionBcrypt
+		if pc.Mode == modeScylla {
+			pc.PwEncryptionAlgorithm = pwEncryptionSHA512
+		}
+	}
+
+	log.Printf("Using mode %s, system keyspace %s and password encryption %s", pc.Mode, pc.SystemKeyspaceName, pc.PwEncryptionAlgorithm)
+}
+
+// releaseVersionAtLeast reports whether the release version reported by the server
+// is at least major.minor. ok is false when the version cannot be parsed.
+func releaseVersionAtLeast(releaseVersion string, major int, minor int) (bool, bool) {
+	parts := strings.SplitN(releaseVersion, ".", 3)
+	if len(parts) < 2 {
+		return false, false
+	}
+	actualMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false, false
+	}
+	// strip suffixes such as 5.0-beta1
+	minorDigits := parts[1]
+	if i := strings.IndexFunc(minorDigits, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minorDigits = minorDigits[:i]
+	}
+	actualMinor, err := strconv.Atoi(minorDigits)
+	if err != nil {
+		return false, false
+	}
+	return actualMajor > major || (actualMajor == major && actualMinor >= minor), true
+}
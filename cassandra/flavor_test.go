@@ -0,0 +1,24 @@
+package cassandra
+
+import "testing"
+
+func TestReleaseVersionAtLeast(t *testing.T) {
+	for _, test := range []struct {
+		version   string
+		supported bool
+		ok        bool
+	}{
+		{"5.0.2", true, true},
+		{"4.1.5", true, true},
+		{"4.0.13", false, true},
+		{"3.11.17", false, true},
+		{"4.1-beta1", true, true},
+		{"unknown", false, false},
+		{"", false, false},
+	} {
+		supported, ok := releaseVersionAtLeast(test.version, 4, 1)
+		if supported != test.supported || ok != test.ok {
+			t.Errorf("expected (%t, %t) for %s, got (%t, %t)", test.supported, test.ok, test.version, supported, ok)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cassandra
+
+import (
+	"log"
+	"net"
+	"sync"
+
+	"github.com/gocql/gocql"
+)
+
+// newHostFilter builds a gocql.HostFilter accepting only the given hosts, given as
+// IP addresses or host names, optionally followed by :port. Unlike
+// gocql.WhiteListHostFilter, which resolves the names when it is built and panics
+// when none resolves, names are resolved when the driver first asks about a host
+// and a name that does not resolve only excludes itself. Unresolved names are
+// looked up again for hosts that are not accepted yet.
+func newHostFilter(hosts []string) gocql.HostFilter {
+	var mu sync.Mutex
+	accepted := make(map[string]bool, len(hosts))
+	var unresolved []string
+	for _, host := range hosts {
+		name, _, err := splitAddress(host)
+		if err != nil {
+			name = host
+		}
+		if ip := net.ParseIP(name); ip != nil {
+			accepted[ip.String()] = true
+		} else {
+			unresolved = append(unresolved, name)
+		}
+	}
+
+	return gocql.HostFilterFunc(func(host *gocql.HostInfo) bool {
+		address := host.ConnectAddress().String()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if accepted[address] {
+			return true
+		}
+		remaining := unresolved[:0]
+		for _, name := range unresolved {
+			ips, err := net.LookupIP(name)
+			if err != nil {
+				log.Printf("[WARN] Unable to resolve host %s: %s", name, err)
+				remaining = append(remaining, name)
+				continue
+			}
+			for _, ip := range ips {
+				accepted[ip.String()] = true
+			}
+		}
+		unresolved = remaining
+		return accepted[address]
+	})
+}
@@ -0,0 +1,26 @@
+package cassandra
+
+import (
+	"net"
+	"testing"
+
+	"github.com/gocql/gocql"
+)
+
+func TestNewHostFilter(t *testing.T) {
+	filter := newHostFilter([]string{"10.0.0.1:9142", "localhost", "unresolvable.invalid"})
+	cases := []struct {
+		address  string
+		accepted bool
+	}{
+		{"10.0.0.1", true},
+		{"127.0.0.1", true},
+		{"10.0.0.2", false},
+	}
+	for _, c := range cases {
+		host := (&gocql.HostInfo{}).SetConnectAddress(net.ParseIP(c.address))
+		if accepted := filter.Accept(host); accepted != c.accepted {
+			t.Errorf("%s: expected accepted to be %t, got %t", c.address, c.accepted, accepted)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package cassandra
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	sha512CryptPrefix        = "$6$"
+	sha512CryptRoundsPrefix  = "rounds="
+	sha512CryptDefaultRounds = 5000
+	sha512CryptMinRounds     = 1000
+	sha512CryptMaxRounds     = 999999999
+	sha512CryptMaxSaltLength = 16
+
+	cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// passwordMatchesHash reports whether password hashes to hash, as stored in
+// salted_hash with algorithm. ok is false when hash is not in the format of
+// algorithm, the password cannot be verified then.
+func passwordMatchesHash(algorithm string, password string, hash string) (match bool, ok bool) {
+	switch algorithm {
+	case pwEncryptionBcrypt:
+		if !bcryptHashRegex.MatchString(hash) {
+			return false, false
+		}
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil, true
+	case pwEncryptionSHA512:
+		computed, err := sha512Crypt(password, hash)
+		if err != nil {
+			return false, false
+		}
+		return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1, true
+	}
+	return false, false
+}
+
+// sha512Crypt hashes password with the SHA-512 based crypt(3) scheme used by
+// Scylla, taking the rounds and salt from setting, e.g. $6$rounds=10000$salt or
+// a complete hash.
+func sha512Crypt(password string, setting string) (string, error) {
+	if !strings.HasPrefix(setting, sha512CryptPrefix) {
+		return "", fmt.Errorf("not a SHA-512 crypt hash")
+	}
+	rest := strings.TrimPrefix(setting, sha512CryptPrefix)
+
+	rounds, customRounds := sha512CryptDefaultRounds, false
+	if strings.HasPrefix(rest, sha512CryptRoundsPrefix) {
+		value, remainder, found := strings.Cut(strings.TrimPrefix(rest, sha512CryptRoundsPrefix), "$")
+		if !found {
+			return "", fmt.Errorf("missing salt after rounds")
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid rounds %s", value)
+		}
+		rounds, customRounds, rest = parsed, true, remainder
+		if rounds < sha512CryptMinRounds {
+			rounds = sha512CryptMinRounds
+		} else if rounds > sha512CryptMaxRounds {
+			rounds = sha512CryptMaxRounds
+		}
+	}
+	salt, _, _ := strings.Cut(rest, "$")
+	if len(salt) > sha512CryptMaxSaltLength {
+		salt = salt[:sha512CryptMaxSaltLength]
+	}
+
+	p, s := []byte(password), []byte(salt)
+
+	alternate := sha512.New()
+	alternate.Write(p)
+	alternate.Write(s)
+	alternate.Write(p)
+	b := alternate.Sum(nil)
+
+	initial := sha512.New()
+	initial.Write(p)
+	initial.Write(s)
+	writeRepeated(initial, b, len(p))
+	for i := len(p); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			initial.Write(b)
+		} else {
+			initial.Write(p)
+		}
+	}
+	a := initial.Sum(nil)
+
+	passwordDigest := sha512.New()
+	for range p {
+		passwordDigest.Write(p)
+	}
+	pSequence := repeatBytes(passwordDigest.Sum(nil), len(p))
+
+	saltDigest := sha512.New()
+	for i := 0; i < 16+int(a[0]); i++ {
+		saltDigest.Write(s)
+	}
+	sSequence := repeatBytes(saltDigest.Sum(nil), len(s))
+
+	c := a
+	for i := 0; i < rounds; i++ {
+		round := sha512.New()
+		if i&1 != 0 {
+			round.Write(pSequence)
+		} else {
+			round.Write(c)
+		}
+		if i%3 != 0 {
+			round.Write(sSequence)
+		}
+		if i%7 != 0 {
+			round.Write(pSequence)
+		}
+		if i&1 != 0 {
+			round.Write(c)
+		} else {
+			round.Write(pSequence)
+		}
+		c = round.Sum(nil)
+	}
+
+	var hash strings.Builder
+	hash.WriteString(sha512CryptPrefix)
+	if customRounds {
+		fmt.Fprintf(&hash, "%s%d$", sha512CryptRoundsPrefix, rounds)
+	}
+	hash.WriteString(salt)
+	hash.WriteString("$")
+	// the digest is encoded in groups of three bytes taken 21 bytes apart
+	for i := 0; i < 21; i++ {
+		group := [3]int{i, i + 21, i + 42}
+		first, second, third := group[i%3], group[(i+1)%3], group[(i+2)%3]
+		writeCryptBase64(&hash, uint(c[first])<<16|uint(c[second])<<8|uint(c[third]), 4)
+	}
+	writeCryptBase64(&hash, uint(c[63]), 2)
+	return hash.String(), nil
+}
+
+// writeRepeated writes length bytes of block, repeated as needed, to w.
+func writeRepeated(w io.Writer, block []byte, length int) {
+	for ; length > len(block); length -= len(block) {
+		w.Write(block)
+	}
+	w.Write(block[:length])
+}
+
+// repeatBytes returns length bytes of block, repeated as needed.
+func repeatBytes(block []byte, length int) []byte {
+	sequence := make([]byte, 0, length)
+	for len(sequence) < length {
+		sequence = append(sequence, block[:min(len(block), length-len(sequence))]...)
+	}
+	return sequence
+}
+
+// writeCryptBase64 writes the n low 6-bit groups of value, least significant first.
+func writeCryptBase64(hash *strings.Builder, value uint, n int) {
+	for ; n > 0; n-- {
+		hash.WriteByte(cryptAlphabet[value&0x3f])
+		value >>= 6
+	}
+}
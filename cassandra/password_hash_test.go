@@ -0,0 +1,54 @@
+package cassandra
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSHA512Crypt(t *testing.T) {
+	cases := []struct {
+		password string
+		setting  string
+		expected string
+	}{
+		{"Hello world!", "$6$saltstring", "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+		{"Hello world!", "$6$rounds=10000$saltstringsaltstring", "$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v."},
+		{strings.Repeat("a", 100), "$6$toolongsaltstring", "$6$toolongsaltstrin$G1Bld/m.KLwEtzwbGU4I2HE/FVbFqJPEIZYUwU8FwvxfAWo.3cU/aHhgiI.bR6pwV.ZIfmQQc6VbJHWmDpVF1/"},
+	}
+	for _, c := range cases {
+		hash, err := sha512Crypt(c.password, c.setting)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", c.setting, err)
+		} else if hash != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, hash)
+		}
+	}
+
+	if _, err := sha512Crypt("asdf1234", "$5$saltstring"); err == nil {
+		t.Error("expected an error for a SHA-256 crypt hash")
+	}
+}
+
+func TestPasswordMatchesHash(t *testing.T) {
+	sha512Hash := "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"
+	cases := []struct {
+		algorithm string
+		password  string
+		hash      string
+		match     bool
+		ok        bool
+	}{
+		{pwEncryptionSHA512, "Hello world!", sha512Hash, true, true},
+		{pwEncryptionSHA512, "Hello world?", sha512Hash, false, true},
+		{pwEncryptionSHA512, "Hello world!", testAccBcryptHash, false, false},
+		{pwEncryptionBcrypt, "asdf1234", testAccBcryptHash, true, true},
+		{pwEncryptionBcrypt, "asdf12345", testAccBcryptHash, false, true},
+		{pwEncryptionBcrypt, "Hello world!", sha512Hash, false, false},
+	}
+	for _, c := range cases {
+		match, ok := passwordMatchesHash(c.algorithm, c.password, c.hash)
+		if match != c.match || ok != c.ok {
+			t.Errorf("%s %q: expected match %t and ok %t, got %t and %t", c.algorithm, c.password, c.match, c.ok, match, ok)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package cassandra
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// passwordPolicy holds the provider level requirements role passwords must meet.
+type passwordPolicy struct {
+	MinLength    int
+	MinLowercase int
+	MinUppercase int
+	MinDigits    int
+	MinSpecial   int
+}
+
+// validate returns an error listing every requirement password does not meet.
+func (p passwordPolicy) validate(password string) error {
+	var length, lowercase, uppercase, digits, special int
+	for _, r := range password {
+		length++
+		switch {
+		case unicode.IsLower(r):
+			lowercase++
+		case unicode.IsUpper(r):
+			uppercase++
+		case unicode.IsDigit(r):
+			digits++
+		default:
+			special++
+		}
+	}
+
+	var violations []string
+	for _, requirement := range []struct {
+		actual, min int
+		what        string
+	}{
+		{length, p.MinLength, "characters"},
+		{lowercase, p.MinLowercase, "lowercase letters"},
+		{uppercase, p.MinUppercase, "uppercase letters"},
+		{digits, p.MinDigits, "digits"},
+		{special, p.MinSpecial, "special characters"},
+	} {
+		if requirement.actual < requirement.min {
+			violations = append(violations, fmt.Sprintf("at least %d %s", requirement.min, requirement.what))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("password does not meet the password policy of the provider, it must contain %s", strings.Join(violations, ", "))
+	}
+	return nil
+}
+
+// validatePasswordPolicy checks a known, changed password, or the write-only
+// password of the configuration, against the password policy of the provider.
+func validatePasswordPolicy(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	providerConfig, ok := meta.(*ProviderConfig)
+	if !ok {
+		return nil
+	}
+	if password := writeOnlyPassword(d); password != "" {
+		return providerConfig.PasswordPolicy.validate(password)
+	}
+	if !d.HasChange("password") || !d.NewValueKnown("password") {
+		return nil
+	}
+	password := d.Get("password").(string)
+	if password == "" {
+		return nil
+	}
+	return providerConfig.PasswordPolicy.validate(password)
+}
+
+const (
+	passwordLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits  = "0123456789"
+	// single quotes are left out, passwords are embedded in string literals
+	passwordSpecial = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+)
+
+// generate returns a random password of at least length characters meeting the
+// policy, drawn from letters, digits and, when special is set, special characters.
+func (p passwordPolicy) generate(length int, special bool) (string, error) {
+	if length < p.MinLength {
+		length = p.MinLength
+	}
+	charset := passwordLetters + passwordDigits
+	if special {
+		charset += passwordSpecial
+	} else if p.MinSpecial > 0 {
+		return "", fmt.Errorf("the password policy of the provider requires special characters, enable generated_password_special")
+	}
+	if p.MinLowercase+p.MinUppercase+p.MinDigits+p.MinSpecial > length {
+		return "", fmt.Errorf("the password policy of the provider requires more characters than the generated length of %d", length)
+	}
+
+	// draw until the password meets the policy, which is quick for any sane policy
+	for attempt := 0; attempt < 1000; attempt++ {
+		password := make([]byte, length)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+			if err != nil {
+				return "", err
+			}
+			password[i] = charset[n.Int64()]
+		}
+		if p.validate(string(password)) == nil {
+			return string(password), nil
+		}
+	}
+	return "", fmt.Errorf("unable to generate a password meeting the password policy of the provider, increase generated_password_length")
+}
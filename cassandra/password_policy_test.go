@@ -0,0 +1,59 @@
+package cassandra
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	policy := passwordPolicy{MinLength: 8}
+	if err := policy.validate("asdf1234"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := policy.validate("asdf"); err == nil || !strings.Contains(err.Error(), "at least 8 characters") {
+		t.Errorf("expected a length violation, got %v", err)
+	}
+
+	policy = passwordPolicy{MinLength: 8, MinLowercase: 1, MinUppercase: 1, MinDigits: 2, MinSpecial: 1}
+	if err := policy.validate("Asdf-1234"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	err := policy.validate("asdfasdf1")
+	if err == nil {
+		t.Fatal("expected violations")
+	}
+	for _, violation := range []string{"at least 1 uppercase letters", "at least 2 digits", "at least 1 special characters"} {
+		if !strings.Contains(err.Error(), violation) {
+			t.Errorf("expected %q in %s", violation, err)
+		}
+	}
+}
+
+func TestPasswordPolicyGenerate(t *testing.T) {
+	policy := passwordPolicy{MinLength: 40, MinLowercase: 2, MinUppercase: 2, MinDigits: 2, MinSpecial: 2}
+	password, err := policy.generate(32, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != 40 {
+		t.Errorf("expected the length to be raised to 40, got %d", len(password))
+	}
+	if err := policy.validate(password); err != nil {
+		t.Errorf("generated password does not meet the policy: %s", err)
+	}
+	if strings.Contains(password, "'") {
+		t.Errorf("generated password %s contains a single quote", password)
+	}
+
+	password, err = passwordPolicy{MinLength: 8}.generate(16, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Trim(password, passwordLetters+passwordDigits) != "" {
+		t.Errorf("expected only letters and digits, got %s", password)
+	}
+
+	if _, err := policy.generate(32, false); err == nil {
+		t.Error("expected an error when the policy requires special characters")
+	}
+}
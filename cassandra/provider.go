@@ -6,6 +6,12 @@ import (
 	"crypto/x509"
 	"fmt"
 	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -34,22 +40,211 @@ var (
 		"EACH_QUORUM":  gocql.EachQuorum,
 		"LOCAL_ONE":    gocql.LocalOne,
 	}
+
+	allowedSerialConsistencies = map[string]gocql.SerialConsistency{
+		"SERIAL":       gocql.Serial,
+		"LOCAL_SERIAL": gocql.LocalSerial,
+	}
 )
 
+// lockDDL blocks until a schema change may be issued, at most max_concurrent_ddl
+// statements run at the same time. The returned function releases the slot.
+func (pc *ProviderConfig) lockDDL(ctx context.Context) (func(), error) {
+	if pc.ddlSemaphore == nil {
+		return func() {}, nil
+	}
+	select {
+	case pc.ddlSemaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := pc.throttleDDL(ctx); err != nil {
+		<-pc.ddlSemaphore
+		return nil, err
+	}
+	return func() { <-pc.ddlSemaphore }, nil
+}
+
+// throttleDDL waits for the next slot allowed by ddl_rate_limit, spacing schema
+// changes evenly so large applies do not flood the cluster with migrations.
+func (pc *ProviderConfig) throttleDDL(ctx context.Context) error {
+	if pc.ddlInterval <= 0 {
+		return nil
+	}
+
+	pc.ddlMu.Lock()
+	now := time.Now()
+	slot := pc.nextDDL
+	if slot.Before(now) {
+		slot = now
+	}
+	pc.nextDDL = slot.Add(pc.ddlInterval)
+	pc.ddlMu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] Delaying schema change by %s to respect ddl_rate_limit", wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// executeDDL runs a schema altering statement and waits until all nodes agree on
+// the resulting schema version, so dependent resources do not race on it.
+func (pc *ProviderConfig) executeDDL(ctx context.Context, session *gocql.Session, query string, values ...interface{}) error {
+	unlock, err := pc.lockDDL(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	q := session.Query(query, values...).WithContext(ctx)
+	var tracer *traceRecorder
+	if pc.EnableTracing {
+		tracer = &traceRecorder{}
+		q = q.Trace(tracer)
+	}
+
+	err = q.Exec()
+	if tracer != nil && tracer.sessionID != "" {
+		log.Printf("[INFO] Schema change traced with session id %s: %s", tracer.sessionID, redactStatement(query))
+		if err != nil {
+			err = fmt.Errorf("%w (trace session id %s)", err, tracer.sessionID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return session.AwaitSchemaAgreement(ctx)
+}
+
+// usingTimeoutClause returns the USING TIMEOUT clause appended to the data
+// manipulation statements of the provider when the cluster runs Scylla. Scylla
+// rejects it on schema changes, which therefore never get one.
+func (pc *ProviderConfig) usingTimeoutClause() string {
+	if pc.Mode != modeScylla || pc.ScyllaUsingTimeout == "" {
+		return ""
+	}
+	return fmt.Sprintf(" USING TIMEOUT %s", pc.ScyllaUsingTimeout)
+}
+
+// allowedConsistencyNames returns the sorted names of the supported consistency levels.
+func allowedConsistencyNames() []string {
+	names := make([]string, 0, len(allowedConsistencies))
+	for name := range allowedConsistencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ProviderConfig wraps the underlying gocql.ClusterConfig and holds additional settings.
 type ProviderConfig struct {
-	Cluster            *gocql.ClusterConfig
-	SystemKeyspaceName string
+	Cluster               *gocql.ClusterConfig
+	FallbackClusters      []*gocql.ClusterConfig
+	Mode                  string
+	SystemKeyspaceName    string
+	PwEncryptionAlgorithm string
+	ReleaseVersion        string
+	AdoptExisting         bool
+	EnableTracing         bool
+	ScyllaUsingTimeout    string
+	AmazonKeyspaces       bool
+	PasswordPolicy        passwordPolicy
+	Username              string
+	AllowSelfLockout      bool
+	AllowSuperuser        bool
+	NegotiateCQLVersion   bool
+
+	detectOnce   sync.Once
+	ddlSemaphore chan struct{}
+	ddlInterval  time.Duration
+	ddlMu        sync.Mutex
+	nextDDL      time.Time
+
+	cqlVersionMu         sync.Mutex
+	cqlVersionNegotiated bool
+}
+
+// connect opens a session against the configured contact points, falling back to
+// each fallback host group in order when the previous ones are unreachable.
+func (pc *ProviderConfig) connect() (*gocql.Session, error) {
+	if pc.NegotiateCQLVersion {
+		pc.applyNegotiatedCQLVersion()
+	}
+	session, err := pc.Cluster.CreateSession()
+	for i, fallback := range pc.FallbackClusters {
+		if err == nil {
+			break
+		}
+		log.Printf("[WARN] Unable to connect to %v, trying fallback host group %d: %s", pc.Cluster.Hosts, i+1, err)
+		session, err = fallback.CreateSession()
+	}
+	if err != nil && pc.Cluster.ProtoVersion != 0 && strings.Contains(strings.ToLower(err.Error()), "unsupported protocol") {
+		return nil, fmt.Errorf("the server does not support CQL protocol version %d, set protocol_version to a version supported by the server or to 0 to negotiate it automatically: %w", pc.Cluster.ProtoVersion, err)
+	}
+	return session, err
+}
+
+// createSession opens a new session against the cluster. When d is not nil the
+// resource level overrides it carries (e.g. consistency) are applied to the session.
+func (pc *ProviderConfig) createSession(d *schema.ResourceData) (*gocql.Session, error) {
+	start := time.Now()
+	session, err := pc.connect()
+	elapsed := time.Since(start)
+	log.Printf("Getting a session took %s", elapsed)
+	if err != nil {
+		return nil, err
+	}
+
+	pc.detectOnce.Do(func() {
+		pc.detectServerFlavor(session)
+	})
+
+	if d != nil {
+		if v, ok := d.GetOk("consistency"); ok {
+			session.SetConsistency(allowedConsistencies[v.(string)])
+		}
+	}
+	return session, nil
 }
 
 // Provider returns a terraform.ResourceProvider
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
-			"cassandra_keyspace": resourceCassandraKeyspace(),
-			"cassandra_role":     resourceCassandraRole(),
-			"cassandra_grant":    resourceCassandraGrant(),
-			"cassandra_table":    resourceCassandraTableSpace(),
+			"cassandra_keyspace":                resourceCassandraKeyspace(),
+			"cassandra_role":                    resourceCassandraRole(),
+			"cassandra_grant":                   resourceCassandraGrant(),
+			"cassandra_table":                   resourceCassandraTableSpace(),
+			"cassandra_system_auth_replication": resourceCassandraSystemAuthReplication(),
+			"cassandra_index":                   resourceCassandraIndex(),
+			"cassandra_type":                    resourceCassandraType(),
+			"cassandra_function":                resourceCassandraFunction(),
+			"cassandra_aggregate":               resourceCassandraAggregate(),
+			"cassandra_search_index":            resourceCassandraSearchIndex(),
+			"cassandra_cql_script":              resourceCassandraCQLScript(),
+			"cassandra_table_rows":              resourceCassandraTableRows(),
+			"cassandra_role_grant":              resourceCassandraRoleGrant(),
+			"cassandra_identity":                resourceCassandraIdentity(),
+			"cassandra_cidr_group":              resourceCassandraCIDRGroup(),
+			"cassandra_role_cidr_access":        resourceCassandraRoleCIDRAccess(),
+			"cassandra_column_mask":             resourceCassandraColumnMask(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"cassandra_keyspace":     dataSourceCassandraKeyspace(),
+			"cassandra_cluster_info": dataSourceCassandraClusterInfo(),
+			"cassandra_nodes":        dataSourceCassandraNodes(),
+			"cassandra_role_grants":  dataSourceCassandraRoleGrants(),
+			"cassandra_settings":     dataSourceCassandraSettings(),
+			"cassandra_clients":      dataSourceCassandraClients(),
 		},
 		ConfigureContextFunc: configureProvider,
 		Schema: map[string]*schema.Schema{
@@ -67,6 +262,143 @@ func Provider() *schema.Provider {
 				Description: "Cassandra password",
 				Sensitive:   true,
 			},
+			"allowed_authenticators": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:      true,
+				Description:   "Server-side authenticator class names accepted during the handshake, e.g. com.datastax.bdp.cassandra.auth.LDAPAuthenticator. Replaces the driver's built-in list when set",
+				ConflictsWith: []string{"auth_passthrough"},
+			},
+			"auth_passthrough": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send plain-text credentials to whichever authenticator the server advertises, skipping the authenticator class check",
+			},
+			"vault": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Read the provider credentials and TLS material from a HashiCorp Vault secret at configure time. Values found in the secret take precedence over the provider attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_ADDR", ""),
+							Description: "Vault address",
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_TOKEN", ""),
+							Description: "Vault token, not needed when logging in with approle",
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("VAULT_NAMESPACE", ""),
+							Description: "Vault Enterprise namespace",
+						},
+						"role_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AppRole role id",
+						},
+						"secret_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "AppRole secret id",
+						},
+						"approle_mount": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "approle",
+							Description: "Mount path of the AppRole auth method",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "API path of the secret, e.g. secret/data/cassandra for a KV version 2 engine",
+						},
+						"username_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "username",
+							Description: "Key of the username in the secret",
+						},
+						"password_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "password",
+							Description: "Key of the password in the secret",
+						},
+						"root_ca_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Key of the PEM encoded root CA in the secret",
+						},
+						"client_cert_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Key of the PEM encoded client certificate in the secret",
+						},
+						"client_key_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Key of the PEM encoded client private key in the secret",
+						},
+					},
+				},
+			},
+			"aws_credentials": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Read the provider credentials from AWS Secrets Manager or SSM Parameter Store at configure time. Values found in the secret take precedence over username and password",
+				ConflictsWith: []string{"vault"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AWS region, defaults to the region of the AWS environment",
+						},
+						"profile": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "AWS shared config profile",
+						},
+						"secret_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "ARN or name of the Secrets Manager secret",
+							ExactlyOneOf: []string{"aws_credentials.0.secret_id", "aws_credentials.0.ssm_parameter"},
+						},
+						"ssm_parameter": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the SSM parameter, SecureString parameters are decrypted",
+						},
+						"username_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "username",
+							Description: "Key of the username when the secret is a JSON object",
+						},
+						"password_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "password",
+							Description: "Key of the password when the secret is a JSON object. A secret that is not a JSON object is used as the password",
+						},
+					},
+				},
+			},
 			"port": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -79,7 +411,7 @@ func Provider() *schema.Provider {
 				DefaultFunc:  schema.EnvDefaultFunc("CASSANDRA_HOST", nil),
 				Description:  "Cassandra host",
 				Optional:     true,
-				ExactlyOneOf: []string{"host", "hosts"},
+				ExactlyOneOf: []string{"host", "hosts", "srv_record"},
 			},
 			"hosts": {
 				Type: schema.TypeList,
@@ -90,18 +422,225 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Cassandra hosts",
 			},
+			"srv_record": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "DNS SRV record (e.g. _cql._tcp.cassandra.example.com) resolved at configure time to build the list of contact points and their ports",
+			},
+			"fallback_host_group": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered groups of contact points, e.g. of a secondary region, tried in turn when the primary hosts are unreachable",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hosts": {
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Required:    true,
+							MinItems:    1,
+							Description: "Contact points of the group",
+						},
+					},
+				},
+			},
 			"host_filter": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 				Description: "Filter all incoming events for host. Hosts have to exist before using this provider",
 			},
+			"host_discovery": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Description:   "When true the driver discovers and connects to every peer of the cluster, when false it only talks to the configured contact points. Shorthand for host_filter and disable_initial_host_lookup",
+				ConflictsWith: []string{"host_filter", "disable_initial_host_lookup"},
+			},
+			"address_translation": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of addresses advertised by the nodes (IP or IP:port) to the address Terraform reaches them on (host or host:port), e.g. behind NAT, PrivateLink or port-forwards",
+			},
 			"connection_timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     1000,
 				Description: "Connection timeout in milliseconds",
 			},
+			"ssh_tunnel": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Connect to the cluster through an SSH bastion host",
+				ConflictsWith: []string{"socks5_proxy"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Bastion host",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							Description:  "Bastion SSH port",
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"user": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "User to authenticate as on the bastion",
+						},
+						"private_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "PEM encoded private key used to authenticate on the bastion",
+						},
+						"private_key_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to the private key used to authenticate on the bastion. Ignored when private_key is set",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Password used to authenticate on the bastion",
+						},
+						"host_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Expected public host key of the bastion in authorized_keys format. The host key is not verified when unset",
+						},
+					},
+				},
+			},
+			"socks5_proxy": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				Description:   "Connect to the cluster through a SOCKS5 proxy",
+				ConflictsWith: []string{"ssh_tunnel"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Proxy address in host:port form",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Proxy username",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Proxy password",
+						},
+					},
+				},
+			},
+			"shard_aware_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      19042,
+				Description:  "Scylla shard-aware port. Connections to Scylla nodes listing it are spread over the shards of each node by their source port. Set the TLS shard-aware port, usually 19142, with use_ssl, and 0 to always connect to port. Not used with mode = cassandra, ssh_tunnel or socks5_proxy",
+				ValidateFunc: validation.IntBetween(0, 65535),
+			},
+			"num_conns": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2,
+				Description:  "Number of connections opened per host",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      5000,
+				Description:  "Default page size used when reading system tables",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_prepared_stmts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				Description:  "Maximum number of prepared statements cached by the driver",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"socket_keepalive": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "TCP keepalive period in seconds, keeps idle connections alive through NAT gateways and firewalls during long applies. 0 disables keepalives",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"write_coalesce_wait_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      200,
+				Description:  "Time in microseconds the driver waits to coalesce writes to a connection. 0 disables write coalescing",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create keyspaces, tables and roles with IF NOT EXISTS and adopt objects that already exist into state instead of failing",
+			},
+			"max_concurrent_ddl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Maximum number of schema changes issued concurrently. Concurrent schema changes can race on the schema version, the default serializes them",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"enable_tracing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Run schema changes with CQL tracing and log the trace session ids, to correlate slow changes with system_traces",
+			},
+			"scylla_using_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Server side timeout appended as USING TIMEOUT to the INSERT and DELETE statements of cassandra_table_rows when the cluster runs Scylla, e.g. 2m or 1m30s. Scylla rejects USING TIMEOUT on schema changes, which are sent without it",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(\d+(ms|us|µs|ns|s|m|h|d))+$`), "must be a Scylla duration like 30s or 1m30s"),
+			},
+			"ddl_rate_limit": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of schema changes issued per second, 0 disables rate limiting",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_schema_agreement_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "Maximum time in seconds to wait for all nodes to agree on the schema after a schema change",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60000,
+				Description:  "Timeout in milliseconds for each query, including schema changes",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"startup_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Time in seconds to keep retrying the initial connection until the cluster accepts sessions. Useful when the cluster is created in the same apply. 0 disables the check",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"root_ca": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -126,6 +665,37 @@ func Provider() *schema.Provider {
 					return nil
 				},
 			},
+			"root_ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded root CA used to connect to Cluster. Applies only when use_ssl is enabled",
+				ConflictsWith: []string{"root_ca"},
+			},
+			"client_cert": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded client certificate presented to the cluster. Applies only when use_ssl is enabled",
+				ConflictsWith: []string{"client_cert_file"},
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded client certificate presented to the cluster. Applies only when use_ssl is enabled",
+				ConflictsWith: []string{"client_cert"},
+			},
+			"client_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "PEM encoded private key of the client certificate. Applies only when use_ssl is enabled",
+				ConflictsWith: []string{"client_key_file"},
+			},
+			"client_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to the PEM encoded private key of the client certificate. Applies only when use_ssl is enabled",
+				ConflictsWith: []string{"client_key"},
+			},
 			"use_ssl": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -140,45 +710,113 @@ func Provider() *schema.Provider {
 				ValidateFunc: validation.StringInSlice([]string{"TLS1.0", "TLS1.1", "TLS1.2", "TLS1.3"}, false),
 			},
 			"protocol_version": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     4,
-				Description: "CQL Binary Protocol Version",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				Description:  "CQL Binary Protocol Version, 0 negotiates the highest version supported by both the driver and the server",
+				ValidateFunc: validation.IntBetween(0, 4),
 			},
 			"consistency": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     gocql.Quorum.String(),
-				Description: "Default consistency level",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      gocql.Quorum.String(),
+				Description:  fmt.Sprintf("Default consistency level used for DDL and system table reads. One of %s", strings.Join(allowedConsistencyNames(), ", ")),
+				ValidateFunc: validation.StringInSlice(allowedConsistencyNames(), false),
+			},
+			"serial_consistency": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Serial consistency level applied to every query issued by the provider - allowed values are SERIAL, LOCAL_SERIAL",
+				ValidateFunc: validation.StringInSlice([]string{"SERIAL", "LOCAL_SERIAL"}, false),
 			},
 			"cql_version": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "3.0.0",
-				Description: "CQL version",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				Description:  "CQL version sent on connection startup, e.g. 3.4.5. auto uses the highest version supported by the cluster",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(auto|\d+\.\d+\.\d+)$`), "must be auto or a version like 3.4.5"),
 			},
 			"keyspace": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Initial Keyspace",
+				Deprecated:    "use session_keyspace instead",
+				ConflictsWith: []string{"session_keyspace"},
+			},
+			"session_keyspace": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Initial Keyspace",
+				Description: "Keyspace the sessions are bound to. Empty by default, system tables are always read fully qualified so no keyspace is required",
 			},
 			"disable_initial_host_lookup": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "Whether the driver will not attempt to get host info from the system.peers table",
 			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      modeAuto,
+				Description:  "Server flavor, one of auto, cassandra, scylla. auto detects the flavor from the cluster on first use",
+				ValidateFunc: validation.StringInSlice([]string{modeAuto, modeCassandra, modeScylla}, false),
+			},
 			"system_keyspace_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "system_auth",
-				Description: "System keyspace name for roles and grants",
+				Description: "System keyspace name for roles and grants. Defaults to system_auth, or system for Scylla releases storing roles there",
 			},
 			"pw_encryption_algorithm": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Default:      "bcrypt",
-				Description:  "Password encryption algorithm. Allowed values: bcrypt, sha-512",
-				ValidateFunc: validation.StringInSlice([]string{"bcrypt", "sha-512"}, false),
+				Description:  "Password encryption algorithm. Allowed values: bcrypt, sha-512. Defaults to sha-512 for Scylla and bcrypt otherwise",
+				ValidateFunc: validation.StringInSlice([]string{pwEncryptionBcrypt, pwEncryptionSHA512}, false),
+			},
+			"allow_self_lockout": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow dropping the role the provider authenticates as, or revoking its login or superuser status. Refused by default, the provider would lock itself out of the cluster mid-apply",
+			},
+			"allow_superuser": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow creating roles with super_user set, or granting it to existing roles. Refused by default, as a safety rail for shared modules",
+			},
+			"password_min_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				Description:  "Minimum number of characters of role passwords",
+				ValidateFunc: validation.IntBetween(1, 512),
+			},
+			"password_min_lowercase": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minimum number of lowercase letters of role passwords",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_uppercase": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minimum number of uppercase letters of role passwords",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_digits": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minimum number of digits of role passwords",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"password_min_special": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minimum number of characters of role passwords that are neither letters nor digits",
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 		},
 	}
@@ -195,9 +833,57 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 	protocolVersion := d.Get("protocol_version").(int)
 	diags := diag.Diagnostics{}
 
+	credentials := &sourcedCredentials{}
+	if v, ok := d.GetOk("vault"); ok {
+		vaultCredentials, err := readVaultCredentials(ctx, v.([]interface{})[0].(map[string]interface{}), time.Millisecond*time.Duration(connectionTimeout)*10)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read credentials from vault",
+				Detail:        err.Error(),
+				AttributePath: cty.Path{cty.GetAttrStep{Name: "vault"}},
+			})
+			return nil, diags
+		}
+		credentials = vaultCredentials
+	}
+	if v, ok := d.GetOk("aws_credentials"); ok {
+		awsCredentials, err := readAWSCredentials(ctx, v.([]interface{})[0].(map[string]interface{}))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to read credentials from AWS",
+				Detail:        err.Error(),
+				AttributePath: cty.Path{cty.GetAttrStep{Name: "aws_credentials"}},
+			})
+			return nil, diags
+		}
+		credentials = awsCredentials
+	}
+	if credentials.Username != "" {
+		username = credentials.Username
+	}
+	if credentials.Password != "" {
+		password = credentials.Password
+	}
+
 	var rawHosts []interface{}
 	if rawHost, ok := d.GetOk("host"); ok {
 		rawHosts = []interface{}{rawHost}
+	} else if srvRecord, ok := d.GetOk("srv_record"); ok {
+		srvHosts, err := resolveSRVRecord(ctx, srvRecord.(string))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Unable to resolve SRV record",
+				Detail:        err.Error(),
+				AttributePath: cty.Path{cty.GetAttrStep{Name: "srv_record"}},
+			})
+			return nil, diags
+		}
+		for _, srvHost := range srvHosts {
+			rawHosts = append(rawHosts, srvHost)
+		}
 	} else {
 		rawHosts = d.Get("hosts").([]interface{})
 	}
@@ -212,31 +898,99 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 	cluster := gocql.NewCluster()
 	cluster.Hosts = hosts
 	cluster.Port = port
-	cluster.Authenticator = &gocql.PasswordAuthenticator{
-		Username: username,
-		Password: password,
+	if d.Get("auth_passthrough").(bool) {
+		cluster.Authenticator = &plainTextAuthenticator{
+			Username: username,
+			Password: password,
+		}
+	} else {
+		allowedAuthenticators := make([]string, 0)
+		for _, v := range d.Get("allowed_authenticators").([]interface{}) {
+			allowedAuthenticators = append(allowedAuthenticators, v.(string))
+		}
+		cluster.Authenticator = &gocql.PasswordAuthenticator{
+			Username:              username,
+			Password:              password,
+			AllowedAuthenticators: allowedAuthenticators,
+		}
 	}
 	cluster.ConnectTimeout = time.Millisecond * time.Duration(connectionTimeout)
-	cluster.Timeout = time.Minute * 1
-	cluster.CQLVersion = d.Get("cql_version").(string)
+	cluster.Timeout = time.Millisecond * time.Duration(d.Get("request_timeout").(int))
+	// gocql defaults to 3.0.0, which is kept when the version cannot be negotiated
+	negotiateCQLVersion := true
+	if cqlVersion := d.Get("cql_version").(string); cqlVersion != "auto" {
+		cluster.CQLVersion = cqlVersion
+		negotiateCQLVersion = false
+	}
+	cluster.QueryObserver = loggingQueryObserver{}
+	cluster.NumConns = d.Get("num_conns").(int)
+	cluster.PageSize = d.Get("page_size").(int)
+	cluster.MaxPreparedStmts = d.Get("max_prepared_stmts").(int)
+	cluster.MaxWaitSchemaAgreement = time.Second * time.Duration(d.Get("max_schema_agreement_wait").(int))
+	cluster.SocketKeepalive = time.Second * time.Duration(d.Get("socket_keepalive").(int))
+	cluster.WriteCoalesceWaitTime = time.Microsecond * time.Duration(d.Get("write_coalesce_wait_time").(int))
 
-	if v, ok := d.GetOk("keyspace"); ok && v.(string) != "" {
+	if v, ok := d.GetOk("session_keyspace"); ok && v.(string) != "" {
+		cluster.Keyspace = v.(string)
+	} else if v, ok := d.GetOk("keyspace"); ok && v.(string) != "" {
 		cluster.Keyspace = v.(string)
 	}
 
 	cluster.Consistency = allowedConsistencies[d.Get("consistency").(string)]
 	cluster.ProtoVersion = protocolVersion
 
+	if v, ok := d.GetOk("serial_consistency"); ok {
+		cluster.SerialConsistency = allowedSerialConsistencies[v.(string)]
+	}
+
 	if hostFilter {
-		cluster.HostFilter = gocql.WhiteListHostFilter(hosts...)
+		cluster.HostFilter = newHostFilter(hosts)
 	}
 
 	if v, ok := d.GetOk("disable_initial_host_lookup"); ok {
 		cluster.DisableInitialHostLookup = v.(bool)
 	}
 
+	// GetOk cannot tell an explicit false apart from unset
+	if hostDiscovery, ok := d.GetOkExists("host_discovery"); ok {
+		if hostDiscovery.(bool) {
+			cluster.HostFilter = nil
+			cluster.DisableInitialHostLookup = false
+		} else {
+			cluster.HostFilter = newHostFilter(hosts)
+			cluster.DisableInitialHostLookup = true
+		}
+	}
+
+	if v, ok := d.GetOk("address_translation"); ok {
+		translator, err := newAddressTranslator(v.(map[string]interface{}))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "Invalid address translation",
+				Detail:        err.Error(),
+				AttributePath: cty.GetAttrPath("address_translation"),
+			})
+			return nil, diags
+		}
+		cluster.AddressTranslator = translator
+	}
+
 	if useSSL {
-		rootCA := d.Get("root_ca").(string)
+		var err error
+		rootCA := credentials.RootCA
+		if rootCA == "" {
+			rootCA, err = readFileOrContent(d.Get("root_ca").(string), d.Get("root_ca_file").(string))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Unable to read root_ca_file",
+					Detail:        err.Error(),
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "root_ca_file"}},
+				})
+				return nil, diags
+			}
+		}
 		minTLSVersion := d.Get("min_tls_version").(string)
 		tlsConfig := &tls.Config{
 			MinVersion: allowedTLSProtocols[minTLSVersion],
@@ -254,15 +1008,185 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 			}
 			tlsConfig.RootCAs = caPool
 		}
+
+		clientCert := credentials.ClientCert
+		if clientCert == "" {
+			clientCert, err = readFileOrContent(d.Get("client_cert").(string), d.Get("client_cert_file").(string))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Unable to read client_cert_file",
+					Detail:        err.Error(),
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "client_cert_file"}},
+				})
+				return nil, diags
+			}
+		}
+		clientKey := credentials.ClientKey
+		if clientKey == "" {
+			clientKey, err = readFileOrContent(d.Get("client_key").(string), d.Get("client_key_file").(string))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       "Unable to read client_key_file",
+					Detail:        err.Error(),
+					AttributePath: cty.Path{cty.GetAttrStep{Name: "client_key_file"}},
+				})
+				return nil, diags
+			}
+		}
+		if clientCert != "" || clientKey != "" {
+			certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to load client certificate",
+					Detail:   err.Error(),
+				})
+				return nil, diags
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+
 		cluster.SslOpts = &gocql.SslOptions{
 			Config: tlsConfig,
 		}
 	}
 
-	systemKeyspaceName := d.Get("system_keyspace_name").(string)
+	if v, ok := d.GetOk("ssh_tunnel"); ok {
+		tunnel := v.([]interface{})[0].(map[string]interface{})
+		privateKey, err := readFileOrContent(tunnel["private_key"].(string), tunnel["private_key_file"].(string))
+		if err != nil {
+			return nil, diag.FromErr(fmt.Errorf("unable to read ssh private key: %w", err))
+		}
+		dialer, err := newSSHTunnelDialer(
+			tunnel["host"].(string),
+			tunnel["port"].(int),
+			tunnel["user"].(string),
+			privateKey,
+			tunnel["password"].(string),
+			tunnel["host_key"].(string),
+			cluster.ConnectTimeout,
+		)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		cluster.Dialer = dialer
+	}
 
-	return &ProviderConfig{
-		Cluster:            cluster,
-		SystemKeyspaceName: systemKeyspaceName,
-	}, diags
+	if v, ok := d.GetOk("socks5_proxy"); ok {
+		socks := v.([]interface{})[0].(map[string]interface{})
+		dialer, err := newSOCKS5Dialer(socks["address"].(string), socks["username"].(string), socks["password"].(string), cluster.ConnectTimeout, cluster.SocketKeepalive)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		cluster.Dialer = dialer
+	}
+
+	// the shard-aware port cannot be reached through a tunnel or proxy dialer
+	if shardAwarePort := d.Get("shard_aware_port").(int); shardAwarePort > 0 && cluster.Dialer == nil && d.Get("mode").(string) != modeCassandra {
+		cluster.Dialer = newShardAwareDialer(cluster, shardAwarePort)
+	}
+
+	var fallbackClusters []*gocql.ClusterConfig
+	for _, rawGroup := range d.Get("fallback_host_group").([]interface{}) {
+		group := rawGroup.(map[string]interface{})
+		groupHosts := make([]string, 0)
+		for _, v := range group["hosts"].([]interface{}) {
+			groupHosts = append(groupHosts, v.(string))
+		}
+		fallbackCluster := *cluster
+		fallbackCluster.Hosts = groupHosts
+		if cluster.HostFilter != nil {
+			fallbackCluster.HostFilter = newHostFilter(groupHosts)
+		}
+		fallbackClusters = append(fallbackClusters, &fallbackCluster)
+	}
+
+	providerConfig := &ProviderConfig{
+		Cluster:               cluster,
+		FallbackClusters:      fallbackClusters,
+		Mode:                  d.Get("mode").(string),
+		SystemKeyspaceName:    d.Get("system_keyspace_name").(string),
+		PwEncryptionAlgorithm: d.Get("pw_encryption_algorithm").(string),
+		AdoptExisting:         d.Get("adopt_existing").(bool),
+		EnableTracing:         d.Get("enable_tracing").(bool),
+		ScyllaUsingTimeout:    d.Get("scylla_using_timeout").(string),
+		Username:              username,
+		AllowSelfLockout:      d.Get("allow_self_lockout").(bool),
+		AllowSuperuser:        d.Get("allow_superuser").(bool),
+		NegotiateCQLVersion:   negotiateCQLVersion,
+		ddlSemaphore:          make(chan struct{}, d.Get("max_concurrent_ddl").(int)),
+		PasswordPolicy: passwordPolicy{
+			MinLength:    d.Get("password_min_length").(int),
+			MinLowercase: d.Get("password_min_lowercase").(int),
+			MinUppercase: d.Get("password_min_uppercase").(int),
+			MinDigits:    d.Get("password_min_digits").(int),
+			MinSpecial:   d.Get("password_min_special").(int),
+		},
+	}
+	if rateLimit := d.Get("ddl_rate_limit").(float64); rateLimit > 0 {
+		providerConfig.ddlInterval = time.Duration(float64(time.Second) / rateLimit)
+	}
+
+	if startupTimeout := d.Get("startup_timeout").(int); startupTimeout > 0 {
+		if err := waitForCluster(ctx, providerConfig, time.Second*time.Duration(startupTimeout)); err != nil {
+			path := cty.GetAttrPath("startup_timeout")
+			switch summary, _ := classifyError(err); summary {
+			case "Authentication failed", "Authentication required":
+				path = cty.GetAttrPath("username")
+			case "Unsupported protocol version":
+				path = cty.GetAttrPath("protocol_version")
+			}
+			return nil, append(diags, errorDiagnostics(fmt.Errorf("cluster is not ready: %w", err), "", path)...)
+		}
+	}
+
+	return providerConfig, diags
+}
+
+// resolveSRVRecord looks up the SRV record and returns its targets as host:port
+// contact points, ordered by priority and weight.
+func resolveSRVRecord(ctx context.Context, name string) ([]string, error) {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("SRV record %s has no targets", name)
+	}
+
+	hosts := make([]string, 0, len(records))
+	for _, record := range records {
+		target := strings.TrimSuffix(record.Target, ".")
+		hosts = append(hosts, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+	return hosts, nil
+}
+
+// waitForCluster retries opening a session with exponential backoff until the
+// cluster accepts connections or the timeout elapses.
+func waitForCluster(ctx context.Context, pc *ProviderConfig, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		session, err := pc.connect()
+		if err == nil {
+			session.Close()
+			log.Printf("Cluster accepted a session after %d attempt(s)", attempt)
+			return nil
+		}
+		log.Printf("Cluster not ready yet (attempt %d): %s", attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cluster did not accept a session within %s: %w", timeout, err)
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
 }
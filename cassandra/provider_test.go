@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -71,6 +72,176 @@ func TestProvider_configure2(t *testing.T) {
 	}
 }
 
+func TestProvider_configureAuthPassthrough(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":         "cassandra",
+		"password":         "cassandra",
+		"host":             "asdf",
+		"auth_passthrough": true,
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+	if _, ok := pc.Cluster.Authenticator.(*plainTextAuthenticator); !ok {
+		t.Fatalf("expected plain text authenticator, got %T", pc.Cluster.Authenticator)
+	}
+}
+
+func TestProvider_configureStartupTimeout(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":           "cassandra",
+		"password":           "cassandra",
+		"host":               "127.0.0.1",
+		"port":               1,
+		"connection_timeout": 100,
+		"startup_timeout":    1,
+	})
+	p := Provider()
+	diags := p.Configure(context.Background(), rc)
+	if !diags.HasError() {
+		t.Fatal("expected configure to fail when the cluster never becomes ready")
+	}
+}
+
+func TestProvider_configureSSHTunnel(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username": "cassandra",
+		"password": "cassandra",
+		"host":     "10.0.0.1",
+		"ssh_tunnel": []interface{}{
+			map[string]interface{}{
+				"host":     "bastion.example.com",
+				"user":     "ubuntu",
+				"password": "secret",
+			},
+		},
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+	if _, ok := pc.Cluster.Dialer.(*sshTunnelDialer); !ok {
+		t.Fatalf("expected ssh tunnel dialer, got %T", pc.Cluster.Dialer)
+	}
+}
+
+func TestProvider_configureHostDiscovery(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":       "cassandra",
+		"password":       "cassandra",
+		"hosts":          []interface{}{"10.0.0.1", "10.0.0.2"},
+		"host_discovery": false,
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+	if pc.Cluster.HostFilter == nil || !pc.Cluster.DisableInitialHostLookup {
+		t.Fatal("expected discovery to be restricted to the configured hosts")
+	}
+}
+
+func TestProvider_configureCQLVersion(t *testing.T) {
+	for _, cqlVersion := range []string{"", "3.4.5"} {
+		config := map[string]interface{}{
+			"username": "cassandra",
+			"password": "cassandra",
+		}
+		if cqlVersion != "" {
+			config["cql_version"] = cqlVersion
+		}
+		p := Provider()
+		err := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pc := p.Meta().(*ProviderConfig)
+		if cqlVersion == "" {
+			if !pc.NegotiateCQLVersion {
+				t.Fatal("expected the CQL version to be negotiated by default")
+			}
+		} else if pc.NegotiateCQLVersion || pc.Cluster.CQLVersion != cqlVersion {
+			t.Fatalf("expected CQL version %s without negotiation, got %s", cqlVersion, pc.Cluster.CQLVersion)
+		}
+	}
+}
+
+func TestProvider_configureUnresolvableHosts(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":       "cassandra",
+		"password":       "cassandra",
+		"hosts":          []interface{}{"unresolvable.invalid"},
+		"host_discovery": false,
+		"fallback_host_group": []interface{}{
+			map[string]interface{}{"hosts": []interface{}{"fallback.unresolvable.invalid"}},
+		},
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+	if pc.Cluster.HostFilter == nil || len(pc.FallbackClusters) != 1 || pc.FallbackClusters[0].HostFilter == nil {
+		t.Fatal("expected the configured and fallback hosts to be filtered")
+	}
+}
+
+func TestProvider_configureScyllaUsingTimeout(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":             "cassandra",
+		"password":             "cassandra",
+		"scylla_using_timeout": "1d1m30s",
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+	pc.Mode = modeCassandra
+	if clause := pc.usingTimeoutClause(); clause != "" {
+		t.Fatalf("expected no USING TIMEOUT on Cassandra, got %s", clause)
+	}
+	pc.Mode = modeScylla
+	if expected := " USING TIMEOUT 1d1m30s"; pc.usingTimeoutClause() != expected {
+		t.Fatalf("expected %s, got %s", expected, pc.usingTimeoutClause())
+	}
+}
+
+func TestProvider_configureDDLRateLimit(t *testing.T) {
+	rc := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"username":       "cassandra",
+		"password":       "cassandra",
+		"ddl_rate_limit": 20,
+	})
+	p := Provider()
+	err := p.Configure(context.Background(), rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pc := p.Meta().(*ProviderConfig)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		unlock, err := pc.lockDDL(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		unlock()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected schema changes to be spaced out, took %s", elapsed)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	url := os.Getenv("CASSANDRA_HOST")
 	if url == "" {
@@ -0,0 +1,45 @@
+package cassandra
+
+import (
+	"context"
+	"log"
+	"regexp"
+
+	"github.com/gocql/gocql"
+)
+
+var passwordLiteralRegex = regexp.MustCompile(`(?i)(PASSWORD\s*=\s*)'(?:[^']|'')*'`)
+
+// redactStatement masks password literals so statements can be logged safely.
+func redactStatement(statement string) string {
+	return passwordLiteralRegex.ReplaceAllString(statement, "${1}'********'")
+}
+
+// loggingQueryObserver logs every statement executed by the provider together
+// with the host it ran on, its latency and outcome. Bound values are never logged.
+type loggingQueryObserver struct{}
+
+func (loggingQueryObserver) ObserveQuery(ctx context.Context, q gocql.ObservedQuery) {
+	host := "<unknown>"
+	if q.Host != nil {
+		host = q.Host.ConnectAddress().String()
+	}
+	latency := q.End.Sub(q.Start)
+	if q.Err != nil {
+		log.Printf("[DEBUG] CQL on %s (attempt %d) failed after %s: %s: %s", host, q.Attempt+1, latency, redactStatement(q.Statement), q.Err)
+		return
+	}
+	log.Printf("[DEBUG] CQL on %s (attempt %d) took %s, %d row(s): %s", host, q.Attempt+1, latency, q.Rows, redactStatement(q.Statement))
+}
+
+// traceRecorder keeps the trace session id of a traced statement so it can be
+// correlated with system_traces on the server.
+type traceRecorder struct {
+	sessionID string
+}
+
+func (t *traceRecorder) Trace(traceID []byte) {
+	if uuid, err := gocql.UUIDFromBytes(traceID); err == nil {
+		t.sessionID = uuid.String()
+	}
+}
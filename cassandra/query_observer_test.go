@@ -0,0 +1,19 @@
+package cassandra
+
+import (
+	"testing"
+)
+
+func TestRedactStatement(t *testing.T) {
+	cases := map[string]string{
+		`CREATE ROLE 'app' WITH PASSWORD = 'secret' AND LOGIN = true`:            `CREATE ROLE 'app' WITH PASSWORD = '********' AND LOGIN = true`,
+		`ALTER ROLE 'app' WITH password='it''s secret' AND LOGIN = true`:         `ALTER ROLE 'app' WITH password='********' AND LOGIN = true`,
+		`CREATE ROLE 'app' WITH HASHED PASSWORD = '$2a$10$abc' AND LOGIN = true`: `CREATE ROLE 'app' WITH HASHED PASSWORD = '********' AND LOGIN = true`,
+		`DROP ROLE 'app'`: `DROP ROLE 'app'`,
+	}
+	for statement, expected := range cases {
+		if actual := redactStatement(statement); actual != expected {
+			t.Errorf("redactStatement(%q) = %q, expected %q", statement, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraAggregate() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage user-defined aggregates. Changes to the functions and initial condition are applied with CREATE OR REPLACE AGGREGATE",
+		CreateContext: resourceAggregateCreate,
+		ReadContext:   resourceAggregateRead,
+		UpdateContext: resourceAggregateUpdate,
+		DeleteContext: resourceAggregateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAggregateImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the aggregate",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace to create the aggregate within, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"argument_types": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Ordered CQL types of the aggregated values. They are part of the aggregate signature, aggregates may be overloaded with different argument types",
+			},
+			"state_function": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the function called for each row, taking the state followed by the argument types and returning the new state",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"state_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "CQL type of the state",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"final_function": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the function called on the final state, its result is the result of the aggregate. The final state is returned when not set",
+			},
+			"initial_condition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CQL literal of the initial state, e.g. 0, 'text' or (0, 0). The initial state is null when not set",
+			},
+			"return_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CQL type returned by the aggregate",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// resourceAggregateImport accepts IDs of the form keyspace.aggregate, or
+// keyspace.aggregate(type, ...) to pick one of overloaded aggregates.
+func resourceAggregateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keyspaceName, name, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.aggregate or keyspace.aggregate(type, ...)", d.Id())
+	}
+
+	var argumentTypes []string
+	signature := false
+	if match := functionSignatureRegex.FindStringSubmatch(name); match != nil {
+		name, signature, argumentTypes = match[1], true, splitTypeList(match[2])
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	rows, err := readAggregateOverloads(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		return nil, err
+	}
+	var matches []map[string]interface{}
+	for _, row := range rows {
+		if types, _ := row["argument_types"].([]string); !signature || sameTypes(types, argumentTypes) {
+			matches = append(matches, row)
+		}
+	}
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("found %d aggregates matching %s, import it as keyspace.aggregate(type, ...) to pick one", len(matches), d.Id())
+	}
+
+	d.SetId(name)
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("argument_types", matches[0]["argument_types"])
+	return []*schema.ResourceData{d}, nil
+}
+
+// readAggregateOverloads returns the rows of system_schema.aggregates describing
+// every overload of the aggregate.
+func readAggregateOverloads(session *gocql.Session, keyspace string, name string) ([]map[string]interface{}, error) {
+	iter := session.Query(`SELECT * FROM system_schema.aggregates WHERE keyspace_name = ? AND aggregate_name = ?`, keyspace, name).Iter()
+	rows, err := iter.SliceMap()
+	if err != nil {
+		return nil, err
+	}
+	return rows, iter.Close()
+}
+
+func generateCreateAggregateQueryString(keyspace string, name string, replace bool, ifNotExists bool, argumentTypes []string, stateFunction string, stateType string, finalFunction string, initialCondition string) string {
+	action := "CREATE AGGREGATE"
+	if replace {
+		action = "CREATE OR REPLACE AGGREGATE"
+	} else if ifNotExists {
+		action = "CREATE AGGREGATE IF NOT EXISTS"
+	}
+
+	query := fmt.Sprintf(`%s %q.%q (%s) SFUNC %q STYPE %s`, action, unquoteIdentifier(keyspace), name, strings.Join(argumentTypes, ", "), stateFunction, stateType)
+	if finalFunction != "" {
+		query += fmt.Sprintf(" FINALFUNC %q", finalFunction)
+	}
+	if initialCondition != "" {
+		query += fmt.Sprintf(" INITCOND %s", initialCondition)
+	}
+	return query
+}
+
+func resourceAggregateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createOrReplaceAggregate(ctx, d, meta, false)
+}
+
+func resourceAggregateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("state_function", "final_function", "initial_condition") {
+		return resourceAggregateRead(ctx, d, meta)
+	}
+	return createOrReplaceAggregate(ctx, d, meta, true)
+}
+
+func createOrReplaceAggregate(ctx context.Context, d *schema.ResourceData, meta interface{}, replace bool) diag.Diagnostics {
+	name := d.Get("name").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	query := generateCreateAggregateQueryString(keyspaceName, name, replace, providerConfig.AdoptExisting, listToArray(d.Get("argument_types")),
+		d.Get("state_function").(string), d.Get("state_type").(string), d.Get("final_function").(string), d.Get("initial_condition").(string))
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Creating or replacing aggregate '%s' in '%s'", name, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(name)
+	diags = append(diags, resourceAggregateRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceAggregateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	argumentTypes := listToArray(d.Get("argument_types"))
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	rows, err := readAggregateOverloads(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+	var row map[string]interface{}
+	for _, overload := range rows {
+		if types, _ := overload["argument_types"].([]string); sameTypes(types, argumentTypes) {
+			row = overload
+		}
+	}
+	if row == nil {
+		log.Printf("[WARN] Aggregate '%s(%s)' no longer exists in '%s', removing it from the state", name, strings.Join(argumentTypes, ", "), keyspaceName)
+		d.SetId("")
+		return nil
+	}
+
+	stateType, _ := row["state_type"].(string)
+	if normalizeCQLType(stateType) == normalizeCQLType(d.Get("state_type").(string)) {
+		stateType = d.Get("state_type").(string)
+	}
+
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("state_function", row["state_func"])
+	d.Set("state_type", stateType)
+	d.Set("final_function", row["final_func"])
+	// the server normalizes the literal, keep the configured spelling unless it was cleared
+	if initialCondition, _ := row["initcond"].(string); initialCondition == "" || d.Get("initial_condition").(string) == "" {
+		d.Set("initial_condition", initialCondition)
+	}
+	d.Set("return_type", row["return_type"])
+	return diags
+}
+
+func resourceAggregateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Deleting aggregate '%s' in '%s'", name, keyspaceName)
+	query := fmt.Sprintf(`DROP AGGREGATE IF EXISTS %q.%q (%s)`, unquoteIdentifier(keyspaceName), name, strings.Join(listToArray(d.Get("argument_types")), ", "))
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
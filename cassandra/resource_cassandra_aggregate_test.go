@@ -0,0 +1,74 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGenerateCreateAggregateQueryString(t *testing.T) {
+	query := generateCreateAggregateQueryString("shop", "average", false, false, []string{"int"}, "avg_state", "tuple<int, bigint>", "avg_final", "(0, 0)")
+	expected := `CREATE AGGREGATE "shop"."average" (int) SFUNC "avg_state" STYPE tuple<int, bigint> FINALFUNC "avg_final" INITCOND (0, 0)`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateCreateAggregateQueryString("shop", "total", true, false, []string{"int"}, "plus", "int", "", "")
+	expected = `CREATE OR REPLACE AGGREGATE "shop"."total" (int) SFUNC "plus" STYPE int`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestAccCassandraAggregate_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraAggregateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_aggregate.total", "state_function", "plus"),
+					resource.TestCheckResourceAttr("cassandra_aggregate.total", "return_type", "int"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraAggregateConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "aggregate_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_function" "plus" {
+  name        = "plus"
+  keyspace    = cassandra_keyspace.keyspace.name
+  return_type = "int"
+  language    = "java"
+  body        = "return a + b;"
+
+  argument {
+    name = "a"
+    type = "int"
+  }
+
+  argument {
+    name = "b"
+    type = "int"
+  }
+}
+
+resource "cassandra_aggregate" "total" {
+  name              = "total"
+  keyspace          = cassandra_keyspace.keyspace.name
+  argument_types    = ["int"]
+  state_function    = cassandra_function.plus.name
+  state_type        = "int"
+  initial_condition = "0"
+}
+`
@@ -0,0 +1,173 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraCIDRGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage CIDR groups of the CIDR authorizer, Cassandra 5.0 and later. Changes take effect once the CIDR groups cache of the nodes is refreshed",
+		CreateContext: resourceCIDRGroupCreate,
+		ReadContext:   resourceCIDRGroupRead,
+		UpdateContext: resourceCIDRGroupUpdate,
+		DeleteContext: resourceCIDRGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the CIDR group",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"cidrs": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.IsCIDRNetwork(0, 128)},
+				Required:    true,
+				MinItems:    1,
+				Description: "Networks of the group in CIDR notation, e.g. 10.0.0.0/8",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// cidrsLiteral returns the CQL literal of cidrs as stored in the cidr_groups
+// table, a set of (address, prefix length) tuples.
+func cidrsLiteral(cidrs []string) (string, error) {
+	sort.Strings(cidrs)
+	tuples := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ip, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		prefixLength, _ := network.Mask.Size()
+		tuples = append(tuples, fmt.Sprintf("('%s', %d)", ip, prefixLength))
+	}
+	return fmt.Sprintf("{%s}", strings.Join(tuples, ", ")), nil
+}
+
+// readCIDRGroup returns the networks of the CIDR group, ok is false when the
+// group does not exist.
+func readCIDRGroup(session *gocql.Session, systemKeyspace string, name string) ([]string, bool, error) {
+	row := make(map[string]interface{})
+	err := session.Query(fmt.Sprintf(`SELECT cidrs FROM %s.cidr_groups WHERE cidr_group = ?`, systemKeyspace), name).MapScan(row)
+	if err == gocql.ErrNotFound {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	tuples, _ := row["cidrs"].([][]interface{})
+	cidrs := make([]string, 0, len(tuples))
+	for _, tuple := range tuples {
+		if len(tuple) != 2 {
+			return nil, false, fmt.Errorf("unexpected CIDR %v in group %s", tuple, name)
+		}
+		ip, _ := tuple[0].(net.IP)
+		prefixLength, _ := tuple[1].(int16)
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, prefixLength))
+	}
+	return cidrs, true, nil
+}
+
+func resourceCIDRGroupCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	var diags diag.Diagnostics
+
+	cidrs, err := cidrsLiteral(setToArray(d.Get("cidrs")))
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("cidrs"))
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	// nodetool updatecidrgroup writes the same table, there is no CQL statement for CIDR groups
+	query := fmt.Sprintf(`UPDATE %s.cidr_groups SET cidrs = %s WHERE cidr_group = ?`, providerConfig.SystemKeyspaceName, cidrs)
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query, name).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(name)
+	diags = append(diags, resourceCIDRGroupRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceCIDRGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceCIDRGroupCreateOrUpdate(ctx, d, meta)
+}
+
+func resourceCIDRGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	cidrs, ok, err := readCIDRGroup(session, providerConfig.SystemKeyspaceName, name)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	if !ok {
+		log.Printf("[WARN] CIDR group '%s' no longer exists, removing it from the state", name)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("cidrs", cidrs)
+	return diags
+}
+
+func resourceCIDRGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("cidrs") {
+		return resourceCIDRGroupRead(ctx, d, meta)
+	}
+	return resourceCIDRGroupCreateOrUpdate(ctx, d, meta)
+}
+
+func resourceCIDRGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	query := fmt.Sprintf(`DELETE FROM %s.cidr_groups WHERE cidr_group = ?`, providerConfig.SystemKeyspaceName)
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query, name).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
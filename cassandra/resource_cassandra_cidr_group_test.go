@@ -0,0 +1,65 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCIDRsLiteral(t *testing.T) {
+	literal, err := cidrsLiteral([]string{"192.168.0.0/16", "10.0.0.0/8", "2001:db8::/32"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{('10.0.0.0', 8), ('192.168.0.0', 16), ('2001:db8::', 32)}`
+	if literal != expected {
+		t.Errorf("expected %s, got %s", expected, literal)
+	}
+
+	if _, err := cidrsLiteral([]string{"10.0.0.0"}); err == nil {
+		t.Error("expected an error for an address without prefix length")
+	}
+}
+
+func TestAccCassandraCIDRGroup_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraCIDRGroupConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_cidr_group.office", "cidrs.#", "2"),
+					resource.TestCheckResourceAttr("cassandra_role_cidr_access.app", "cidr_groups.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_cidr_group.office",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "cassandra_role_cidr_access.app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraCIDRGroupConfig = `
+resource "cassandra_cidr_group" "office" {
+  name  = "office"
+  cidrs = ["10.0.0.0/8", "192.168.0.0/16"]
+}
+
+resource "cassandra_role" "app" {
+  name     = "app"
+  password = "app-password-with-at-least-forty-characters!"
+}
+
+resource "cassandra_role_cidr_access" "app" {
+  role        = cassandra_role.app.name
+  cidr_groups = [cassandra_cidr_group.office.name]
+}
+`
@@ -0,0 +1,193 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraColumnMask() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Mask a column with dynamic data masking, Cassandra 5.0 and later. Roles without the UNMASK permission read the masked value",
+		CreateContext: resourceColumnMaskCreateOrUpdate,
+		ReadContext:   resourceColumnMaskRead,
+		UpdateContext: resourceColumnMaskCreateOrUpdate,
+		DeleteContext: resourceColumnMaskDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceColumnMaskImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace of the table, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"table": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the table",
+			},
+			"column": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the masked column",
+			},
+			"function": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Masking function, e.g. mask_default, mask_null, mask_inner or a user-defined function as keyspace.function",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"arguments": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "CQL literals passed to the masking function after the column value, e.g. [\"1\", \"null\"] for mask_inner",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+func columnMaskID(keyspace string, table string, column string) string {
+	return fmt.Sprintf("%s.%s.%s", keyspace, table, column)
+}
+
+// resourceColumnMaskImport accepts IDs of the form keyspace.table.column.
+func resourceColumnMaskImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ".", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.table.column", d.Id())
+	}
+
+	d.Set("keyspace", parts[0])
+	d.Set("table", parts[1])
+	d.Set("column", parts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+func generateColumnMaskQueryString(keyspace string, table string, column string, function string, arguments []string) string {
+	query := fmt.Sprintf(`ALTER TABLE %q.%q ALTER %q`, unquoteIdentifier(keyspace), table, column)
+	if function == "" {
+		return query + " DROP MASKED"
+	}
+	return fmt.Sprintf(`%s MASKED WITH %s(%s)`, query, function, strings.Join(arguments, ", "))
+}
+
+func resourceColumnMaskCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	column := d.Get("column").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	query := generateColumnMaskQueryString(keyspaceName, table, column, d.Get("function").(string), listToArray(d.Get("arguments")))
+	log.Printf("Masking column '%s' of '%s' in '%s'", column, table, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(columnMaskID(keyspaceName, table, column))
+	diags = append(diags, resourceColumnMaskRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceColumnMaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	column := d.Get("column").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	var (
+		functionKeyspace string
+		functionName     string
+		argumentValues   []*string
+	)
+	err := session.Query(`SELECT function_keyspace, function_name, function_argument_values FROM system_schema.column_masks WHERE keyspace_name = ? AND table_name = ? AND column_name = ?`,
+		unquoteIdentifier(keyspaceName), table, column).WithContext(ctx).Scan(&functionKeyspace, &functionName, &argumentValues)
+	if err == gocql.ErrNotFound {
+		log.Printf("[WARN] Column '%s' of '%s' in '%s' is no longer masked, removing it from the state", column, table, keyspaceName)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+
+	// native masking functions live in the system keyspace, keep the configured spelling
+	function := d.Get("function").(string)
+	if function != functionName && function != fmt.Sprintf("%s.%s", functionKeyspace, functionName) {
+		function = functionName
+		if functionKeyspace != "system" {
+			function = fmt.Sprintf("%s.%s", functionKeyspace, functionName)
+		}
+	}
+
+	arguments := make([]string, 0, len(argumentValues))
+	for _, value := range argumentValues {
+		if value == nil {
+			arguments = append(arguments, "null")
+		} else {
+			arguments = append(arguments, *value)
+		}
+	}
+	// string literals are stored unquoted, keep the configured literals when they match
+	configured := listToArray(d.Get("arguments"))
+	if len(configured) == len(arguments) {
+		for i := range arguments {
+			if strings.Trim(configured[i], "'") == arguments[i] {
+				arguments[i] = configured[i]
+			}
+		}
+	}
+
+	d.Set("keyspace", keyspaceName)
+	d.Set("table", table)
+	d.Set("column", column)
+	d.Set("function", function)
+	d.Set("arguments", arguments)
+	return diags
+}
+
+func resourceColumnMaskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	column := d.Get("column").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	query := generateColumnMaskQueryString(keyspaceName, table, column, "", nil)
+	log.Printf("Unmasking column '%s' of '%s' in '%s'", column, table, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
@@ -0,0 +1,77 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGenerateColumnMaskQueryString(t *testing.T) {
+	query := generateColumnMaskQueryString("shop", "users", "email", "mask_inner", []string{"1", "null"})
+	expected := `ALTER TABLE "shop"."users" ALTER "email" MASKED WITH mask_inner(1, null)`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateColumnMaskQueryString(`"Shop"`, "users", "email", "", nil)
+	expected = `ALTER TABLE "Shop"."users" ALTER "email" DROP MASKED`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestAccCassandraColumnMask_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraColumnMaskConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_column_mask.email", "function", "mask_inner"),
+					resource.TestCheckResourceAttr("cassandra_column_mask.email", "arguments.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_column_mask.email",
+				ImportStateId:     "masking.users.email",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraColumnMaskConfig = `
+resource "cassandra_keyspace" "masking" {
+  name                 = "masking"
+  replication_strategy = "SimpleStrategy"
+  strategy_options = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_table" "users" {
+  keyspace       = cassandra_keyspace.masking.name
+  name           = "users"
+  partition_keys = ["id"]
+
+  column {
+    name = "id"
+    type = "uuid"
+  }
+
+  column {
+    name = "email"
+    type = "text"
+  }
+}
+
+resource "cassandra_column_mask" "email" {
+  keyspace  = cassandra_table.users.keyspace
+  table     = cassandra_table.users.name
+  column    = "email"
+  function  = "mask_inner"
+  arguments = ["1", "null"]
+}
+`
@@ -0,0 +1,153 @@
+package cassandra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraCQLScript() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Execute CQL statements for schema constructs the provider does not model. The statements are not read back, changes made outside of Terraform are not detected",
+		CreateContext: resourceCQLScriptCreate,
+		ReadContext:   resourceCQLScriptRead,
+		UpdateContext: resourceCQLScriptUpdate,
+		DeleteContext: resourceCQLScriptDelete,
+		CustomizeDiff: forceNewOnCQLScriptChange,
+		Schema: map[string]*schema.Schema{
+			"create_statements": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Required:    true,
+				MinItems:    1,
+				Description: "Statements executed in order on create, one statement per element. Without recreate_on_change they are executed again on change and should be idempotent, e.g. use IF NOT EXISTS",
+			},
+			"destroy_statements": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsNotWhiteSpace},
+				Optional:    true,
+				Description: "Statements executed in order on destroy, one statement per element",
+			},
+			"recreate_on_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Run the destroy statements, then the create statements, when the create statements change",
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 checksum of the create statements",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// cqlScriptChecksum returns the hex encoded SHA-256 checksum of statements.
+func cqlScriptChecksum(statements []string) string {
+	checksum := sha256.Sum256([]byte(strings.Join(statements, "\n")))
+	return hex.EncodeToString(checksum[:])
+}
+
+// forceNewOnCQLScriptChange updates the checksum when the create statements
+// change, and replaces the resource when recreate_on_change is set.
+func forceNewOnCQLScriptChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("create_statements") {
+		return nil
+	}
+	if !d.NewValueKnown("create_statements") {
+		if err := d.SetNewComputed("checksum"); err != nil {
+			return err
+		}
+	} else if err := d.SetNew("checksum", cqlScriptChecksum(listToArray(d.Get("create_statements")))); err != nil {
+		return err
+	}
+	if d.Get("recreate_on_change").(bool) {
+		return d.ForceNew("create_statements")
+	}
+	return nil
+}
+
+// isSchemaStatement reports whether statement changes the schema, i.e. creates,
+// alters or drops a keyspace, table, type, index, view, function or aggregate.
+// Roles and users are created, altered and dropped without a schema change.
+func isSchemaStatement(statement string) bool {
+	words := strings.Fields(strings.ToUpper(statement))
+	if len(words) < 2 {
+		return false
+	}
+	switch words[0] {
+	case "CREATE", "ALTER", "DROP":
+		return words[1] != "ROLE" && words[1] != "USER"
+	}
+	return false
+}
+
+// executeCQLScript runs statements in order, stopping at the first failure. Only
+// schema changes are serialized and wait for schema agreement.
+func executeCQLScript(ctx context.Context, d *schema.ResourceData, meta interface{}, attribute string) diag.Diagnostics {
+	statements := listToArray(d.Get(attribute))
+	if len(statements) == 0 {
+		return nil
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	for i, statement := range statements {
+		log.Printf("Executing statement %d of %s", i+1, attribute)
+		var err error
+		if isSchemaStatement(statement) {
+			err = providerConfig.executeDDL(ctx, session, statement)
+		} else {
+			err = session.Query(statement).WithContext(ctx).Exec()
+		}
+		if err != nil {
+			return errorDiagnostics(err, statement, cty.GetAttrPath(attribute).IndexInt(i))
+		}
+	}
+	return nil
+}
+
+func resourceCQLScriptCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := executeCQLScript(ctx, d, meta, "create_statements"); diags.HasError() {
+		return diags
+	}
+
+	checksum := cqlScriptChecksum(listToArray(d.Get("create_statements")))
+	d.SetId(checksum)
+	d.Set("checksum", checksum)
+	return nil
+}
+
+// resourceCQLScriptRead keeps the state as is, arbitrary statements cannot be read back.
+func resourceCQLScriptRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceCQLScriptUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("create_statements") {
+		return nil
+	}
+	if diags := executeCQLScript(ctx, d, meta, "create_statements"); diags.HasError() {
+		return diags
+	}
+	d.Set("checksum", cqlScriptChecksum(listToArray(d.Get("create_statements"))))
+	return nil
+}
+
+func resourceCQLScriptDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return executeCQLScript(ctx, d, meta, "destroy_statements")
+}
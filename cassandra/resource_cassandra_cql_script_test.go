@@ -0,0 +1,69 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestCQLScriptChecksum(t *testing.T) {
+	checksum := cqlScriptChecksum([]string{"CREATE TABLE a (id int PRIMARY KEY)"})
+	if len(checksum) != 64 {
+		t.Errorf("expected a hex encoded SHA-256 checksum, got %s", checksum)
+	}
+	if checksum == cqlScriptChecksum([]string{"CREATE TABLE a (id int PRIMARY KEY)", ""}) {
+		t.Error("expected the checksum to change with the statements")
+	}
+}
+
+func TestIsSchemaStatement(t *testing.T) {
+	for statement, expected := range map[string]bool{
+		"CREATE TABLE a (id int PRIMARY KEY)": true,
+		"  create or replace function f () returns null on null input returns int language java as 'return 1;'": true,
+		"ALTER TABLE a ADD name text":          true,
+		"drop materialized view v":             true,
+		"INSERT INTO a (id) VALUES (1)":        false,
+		"UPDATE a SET name = 'x' WHERE id = 1": false,
+		"CREATE ROLE app WITH LOGIN = true":    false,
+		"DROP USER app":                        false,
+		"GRANT SELECT ON a TO app":             false,
+	} {
+		if isSchemaStatement(statement) != expected {
+			t.Errorf("expected %t for %s", expected, statement)
+		}
+	}
+}
+
+func TestAccCassandraCQLScript_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraCQLScriptConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("cassandra_cql_script.view", "checksum"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraCQLScriptConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "cql_script_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_cql_script" "view" {
+  create_statements = [
+    "CREATE TABLE IF NOT EXISTS ${cassandra_keyspace.keyspace.name}.events (id int PRIMARY KEY, kind text)",
+  ]
+  destroy_statements = [
+    "DROP TABLE IF EXISTS ${cassandra_keyspace.keyspace.name}.events",
+  ]
+}
+`
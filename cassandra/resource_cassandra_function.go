@@ -0,0 +1,351 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var functionSignatureRegex = regexp.MustCompile(`^([^(]+)\((.*)\)$`)
+
+func resourceCassandraFunction() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage user-defined functions. Changes to the body, language and null input behaviour are applied with CREATE OR REPLACE FUNCTION",
+		CreateContext: resourceFunctionCreate,
+		ReadContext:   resourceFunctionRead,
+		UpdateContext: resourceFunctionUpdate,
+		DeleteContext: resourceFunctionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFunctionImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the function",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace to create the function within, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"argument": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the argument",
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "CQL type of the argument",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Ordered arguments of the function. Their types are part of the function signature, functions may be overloaded with different argument types",
+			},
+			"return_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "CQL type returned by the function",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"language": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Language the body is written in, e.g. java or javascript. The language must be enabled on the cluster",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"called_on_null_input": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Call the function when an argument is null. By default the function returns null without being called",
+			},
+			"deterministic": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Declare the function DETERMINISTIC, supported by DataStax Enterprise",
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Body of the function",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// resourceFunctionImport accepts IDs of the form keyspace.function, or
+// keyspace.function(type, ...) to pick one of overloaded functions.
+func resourceFunctionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keyspaceName, name, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.function or keyspace.function(type, ...)", d.Id())
+	}
+
+	var argumentTypes []string
+	signature := false
+	if match := functionSignatureRegex.FindStringSubmatch(name); match != nil {
+		name, signature, argumentTypes = match[1], true, splitTypeList(match[2])
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	rows, err := readFunctionOverloads(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		return nil, err
+	}
+	var matches []map[string]interface{}
+	for _, row := range rows {
+		if types, _ := row["argument_types"].([]string); !signature || sameTypes(types, argumentTypes) {
+			matches = append(matches, row)
+		}
+	}
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("found %d functions matching %s, import it as keyspace.function(type, ...) to pick one", len(matches), d.Id())
+	}
+
+	d.SetId(name)
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("argument", flattenFunctionArguments(matches[0]))
+	return []*schema.ResourceData{d}, nil
+}
+
+// splitTypeList splits a comma separated list of CQL types, keeping the commas
+// nested in collection types.
+func splitTypeList(list string) []string {
+	var types []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '<', '(':
+			depth++
+		case '>', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				types = append(types, strings.TrimSpace(list[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(list[start:]); last != "" {
+		types = append(types, last)
+	}
+	return types
+}
+
+// sameTypes reports whether both lists hold equivalent CQL types.
+func sameTypes(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if normalizeCQLType(a[i]) != normalizeCQLType(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func functionArguments(d *schema.ResourceData) ([]string, []string) {
+	var names, types []string
+	for _, rawArgument := range d.Get("argument").([]interface{}) {
+		argument := rawArgument.(map[string]interface{})
+		names = append(names, argument["name"].(string))
+		types = append(types, argument["type"].(string))
+	}
+	return names, types
+}
+
+func flattenFunctionArguments(row map[string]interface{}) []interface{} {
+	names, _ := row["argument_names"].([]string)
+	types, _ := row["argument_types"].([]string)
+	arguments := make([]interface{}, 0, len(names))
+	for i, name := range names {
+		arguments = append(arguments, map[string]interface{}{
+			"name": name,
+			"type": types[i],
+		})
+	}
+	return arguments
+}
+
+// readFunctionOverloads returns the rows of system_schema.functions describing
+// every overload of the function.
+func readFunctionOverloads(session *gocql.Session, keyspace string, name string) ([]map[string]interface{}, error) {
+	iter := session.Query(`SELECT * FROM system_schema.functions WHERE keyspace_name = ? AND function_name = ?`, keyspace, name).Iter()
+	rows, err := iter.SliceMap()
+	if err != nil {
+		return nil, err
+	}
+	return rows, iter.Close()
+}
+
+// dollarQuote quotes body as a CQL string constant, which unlike single quoted
+// strings needs no escaping.
+func dollarQuote(body string) string {
+	if strings.Contains(body, "$$") {
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(body, "'", "''"))
+	}
+	return fmt.Sprintf("$$%s$$", body)
+}
+
+func generateCreateFunctionQueryString(keyspace string, name string, replace bool, ifNotExists bool, argumentNames []string, argumentTypes []string, returnType string, language string, calledOnNullInput bool, deterministic bool, body string) string {
+	arguments := make([]string, 0, len(argumentNames))
+	for i, argumentName := range argumentNames {
+		arguments = append(arguments, fmt.Sprintf("%q %s", argumentName, argumentTypes[i]))
+	}
+
+	nullInput := "RETURNS NULL ON NULL INPUT"
+	if calledOnNullInput {
+		nullInput = "CALLED ON NULL INPUT"
+	}
+	action := "CREATE FUNCTION"
+	if replace {
+		action = "CREATE OR REPLACE FUNCTION"
+	} else if ifNotExists {
+		action = "CREATE FUNCTION IF NOT EXISTS"
+	}
+	query := fmt.Sprintf(`%s %q.%q (%s) %s RETURNS %s`, action, unquoteIdentifier(keyspace), name, strings.Join(arguments, ", "), nullInput, returnType)
+	if deterministic {
+		query += " DETERMINISTIC"
+	}
+	return fmt.Sprintf("%s LANGUAGE %s AS %s", query, language, dollarQuote(body))
+}
+
+func resourceFunctionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createOrReplaceFunction(ctx, d, meta, false)
+}
+
+func resourceFunctionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return createOrReplaceFunction(ctx, d, meta, true)
+}
+
+func createOrReplaceFunction(ctx context.Context, d *schema.ResourceData, meta interface{}, replace bool) diag.Diagnostics {
+	name := d.Get("name").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	argumentNames, argumentTypes := functionArguments(d)
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	query := generateCreateFunctionQueryString(keyspaceName, name, replace, providerConfig.AdoptExisting, argumentNames, argumentTypes, d.Get("return_type").(string), d.Get("language").(string),
+		d.Get("called_on_null_input").(bool), d.Get("deterministic").(bool), d.Get("body").(string))
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Creating or replacing function '%s' in '%s'", name, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(name)
+	diags = append(diags, resourceFunctionRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceFunctionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	_, argumentTypes := functionArguments(d)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	rows, err := readFunctionOverloads(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+	var row map[string]interface{}
+	for _, overload := range rows {
+		if types, _ := overload["argument_types"].([]string); sameTypes(types, argumentTypes) {
+			row = overload
+		}
+	}
+	if row == nil {
+		log.Printf("[WARN] Function '%s(%s)' no longer exists in '%s', removing it from the state", name, strings.Join(argumentTypes, ", "), keyspaceName)
+		d.SetId("")
+		return nil
+	}
+
+	// the argument types identify the function, keep their spelling in the configuration
+	arguments := flattenFunctionArguments(row)
+	for i, rawArgument := range d.Get("argument").([]interface{}) {
+		arguments[i].(map[string]interface{})["type"] = rawArgument.(map[string]interface{})["type"]
+	}
+	returnType, _ := row["return_type"].(string)
+	if normalizeCQLType(returnType) == normalizeCQLType(d.Get("return_type").(string)) {
+		returnType = d.Get("return_type").(string)
+	}
+
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("argument", arguments)
+	d.Set("return_type", returnType)
+	d.Set("language", row["language"])
+	d.Set("called_on_null_input", row["called_on_null_input"])
+	d.Set("body", row["body"])
+	if deterministic, ok := row["deterministic"].(bool); ok {
+		d.Set("deterministic", deterministic)
+	}
+	return diags
+}
+
+func resourceFunctionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	_, argumentTypes := functionArguments(d)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Deleting function '%s' in '%s'", name, keyspaceName)
+	query := fmt.Sprintf(`DROP FUNCTION IF EXISTS %q.%q (%s)`, unquoteIdentifier(keyspaceName), name, strings.Join(argumentTypes, ", "))
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
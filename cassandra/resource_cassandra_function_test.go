@@ -0,0 +1,83 @@
+package cassandra
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGenerateCreateFunctionQueryString(t *testing.T) {
+	query := generateCreateFunctionQueryString("shop", "plus", false, false, []string{"a", "b"}, []string{"int", "int"}, "int", "java", false, false, "return a + b;")
+	expected := `CREATE FUNCTION "shop"."plus" ("a" int, "b" int) RETURNS NULL ON NULL INPUT RETURNS int LANGUAGE java AS $$return a + b;$$`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateCreateFunctionQueryString("shop", "now_or", true, false, nil, nil, "text", "java", true, true, "return \"$$\";")
+	expected = `CREATE OR REPLACE FUNCTION "shop"."now_or" () CALLED ON NULL INPUT RETURNS text DETERMINISTIC LANGUAGE java AS 'return "$$";'`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestSplitTypeList(t *testing.T) {
+	types := splitTypeList("int, map<text, int>, frozen<tuple<int, text>>")
+	expected := []string{"int", "map<text, int>", "frozen<tuple<int, text>>"}
+	if !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected %v, got %v", expected, types)
+	}
+	if types := splitTypeList(""); len(types) != 0 {
+		t.Errorf("expected no types, got %v", types)
+	}
+}
+
+func TestAccCassandraFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraFunctionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_function.plus", "return_type", "int"),
+					resource.TestCheckResourceAttr("cassandra_function.plus", "argument.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_function.plus",
+				ImportStateId:     "function_test.plus(int, int)",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraFunctionConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "function_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_function" "plus" {
+  name        = "plus"
+  keyspace    = cassandra_keyspace.keyspace.name
+  return_type = "int"
+  language    = "java"
+  body        = "return a + b;"
+
+  argument {
+    name = "a"
+    type = "int"
+  }
+
+  argument {
+    name = "b"
+    type = "int"
+  }
+}
+`
@@ -1,27 +1,19 @@
 package cassandra
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"log"
 	"regexp"
 	"strings"
 
+	"github.com/gocql/gocql"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-const (
-	deleteGrantRawTemplate = `REVOKE {{ .Privilege }} ON {{.ResourceType}} {{if .Keyspace }}"{{ .Keyspace}}"{{end}}{{if and .Keyspace .Identifier}}.{{end}}{{if .Identifier}}"{{.Identifier}}"{{end}} FROM "{{.Grantee}}"`
-	createGrantRawTemplate = `GRANT {{ .Privilege }} ON {{.ResourceType}} {{if .Keyspace }}"{{ .Keyspace}}"{{end}}{{if and .Keyspace .Identifier}}.{{end}}{{if .Identifier}}"{{.Identifier}}"{{end}} TO "{{.Grantee}}"`
-)
-
-const templateReadGrant = `SELECT permissions FROM {{.SystemKeyspace}}.role_permissions where resource='data/{{if .Keyspace }}{{ .Keyspace }}{{end}}{{if and .Keyspace .Identifier}}/{{end}}{{if .Identifier}}{{.Identifier}}{{end}}' and role='{{.Grantee}}' ALLOW FILTERING;`
-
 const (
 	privilegeAll       = "all"
 	privilegeCreate    = "create"
@@ -32,12 +24,16 @@ const (
 	privilegeAuthorize = "authorize"
 	privilegeDescribe  = "describe"
 	privilegeExecute   = "execute"
+	// dynamic data masking, Cassandra 5.0 and later
+	privilegeUnmask       = "unmask"
+	privilegeSelectMasked = "select_masked"
 
 	resourceAllFunctions           = "all functions"
 	resourceAllFunctionsInKeyspace = "all functions in keyspace"
 	resourceFunction               = "function"
 	resourceAllKeyspaces           = "all keyspaces"
 	resourceKeyspace               = "keyspace"
+	resourceAllTablesInKeyspace    = "all tables in keyspace"
 	resourceTable                  = "table"
 	resourceAllRoles               = "all roles"
 	resourceRole                   = "role"
@@ -46,6 +42,9 @@ const (
 	resourceMbeans                 = "mbeans"
 	resourceAllMbeans              = "all mbeans"
 
+	// the table, function, role or mbean name of a grant identity
+	grantIdentityIdentifier = "identifier"
+
 	identifierFunctionName = "function_name"
 	identifierTableName    = "table_name"
 	identifierMbeanName    = "mbean_name"
@@ -55,25 +54,27 @@ const (
 	identifierGrantee      = "grantee"
 	identifierPrivilege    = "privilege"
 	identifierResourceType = "resource_type"
+	identifierConsistency  = "consistency"
+	identifierPrivileges   = "privileges"
 )
 
 var (
-	templateDelete, _           = template.New("delete_grant").Parse(deleteGrantRawTemplate)
-	templateCreate, _           = template.New("create_grant").Parse(createGrantRawTemplate)
 	validIdentifierRegex, _     = regexp.Compile(`^[^"]{1,256}$`)
 	validTableNameRegex, _      = regexp.Compile(`^[a-zA-Z0-9][a-zA-Z0-9_]{0,255}`)
-	allPrivileges               = []string{privilegeSelect, privilegeCreate, privilegeAlter, privilegeDrop, privilegeModify, privilegeAuthorize, privilegeDescribe, privilegeExecute}
-	allResources                = []string{resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceAllRoles, resourceRole, resourceRoles, resourceMbean, resourceMbeans, resourceAllMbeans}
+	allPrivileges               = []string{privilegeSelect, privilegeCreate, privilegeAlter, privilegeDrop, privilegeModify, privilegeAuthorize, privilegeDescribe, privilegeExecute, privilegeUnmask, privilegeSelectMasked}
+	allResources                = []string{resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllRoles, resourceRole, resourceRoles, resourceMbean, resourceMbeans, resourceAllMbeans}
 	privilegeToResourceTypesMap = map[string][]string{
-		privilegeAll:       {resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceAllRoles, resourceRole},
-		privilegeCreate:    {resourceAllKeyspaces, resourceKeyspace, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceAllRoles},
-		privilegeAlter:     {resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllRoles, resourceRole},
-		privilegeDrop:      {resourceKeyspace, resourceTable, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllRoles, resourceRole},
-		privilegeSelect:    {resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceAllMbeans, resourceMbeans, resourceMbean},
-		privilegeModify:    {resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceAllMbeans, resourceMbeans, resourceMbean},
-		privilegeAuthorize: {resourceAllKeyspaces, resourceKeyspace, resourceTable, resourceFunction, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceAllRoles, resourceRoles},
-		privilegeDescribe:  {resourceAllRoles, resourceAllMbeans},
-		privilegeExecute:   {resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction},
+		privilegeAll:          {resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllRoles, resourceRole},
+		privilegeCreate:       {resourceAllKeyspaces, resourceKeyspace, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceAllRoles},
+		privilegeAlter:        {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllRoles, resourceRole},
+		privilegeDrop:         {resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction, resourceAllRoles, resourceRole},
+		privilegeSelect:       {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllMbeans, resourceMbeans, resourceMbean},
+		privilegeModify:       {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceAllMbeans, resourceMbeans, resourceMbean},
+		privilegeAuthorize:    {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable, resourceFunction, resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceAllRoles, resourceRoles},
+		privilegeDescribe:     {resourceAllRoles, resourceAllMbeans},
+		privilegeExecute:      {resourceAllFunctions, resourceAllFunctionsInKeyspace, resourceFunction},
+		privilegeUnmask:       {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable},
+		privilegeSelectMasked: {resourceAllKeyspaces, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable},
 	}
 	validResources = map[string]bool{
 		resourceAllFunctions:           true,
@@ -81,6 +82,7 @@ var (
 		resourceFunction:               true,
 		resourceAllKeyspaces:           true,
 		resourceKeyspace:               true,
+		resourceAllTablesInKeyspace:    true,
 		resourceTable:                  true,
 		resourceAllRoles:               true,
 		resourceRole:                   true,
@@ -89,7 +91,7 @@ var (
 		resourceMbeans:                 true,
 		resourceAllMbeans:              true,
 	}
-	resourcesThatRequireKeyspaceQualifier = []string{resourceAllFunctionsInKeyspace, resourceFunction, resourceKeyspace, resourceTable}
+	resourcesThatRequireKeyspaceQualifier = []string{resourceAllFunctionsInKeyspace, resourceFunction, resourceKeyspace, resourceAllTablesInKeyspace, resourceTable}
 	resourceTypeToIdentifier              = map[string]string{
 		resourceFunction: identifierFunctionName,
 		resourceMbean:    identifierMbeanName,
@@ -129,6 +131,20 @@ func resourceCassandraGrant() *schema.Resource {
 		ReadContext:   resourceGrantRead,
 		UpdateContext: resourceGrantUpdate,
 		DeleteContext: resourceGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGrantImport,
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					identifierGrantee:       identityAttributeSchema(true, "Role the privilege is granted to"),
+					identifierResourceType:  identityAttributeSchema(true, "Resource type of the grant"),
+					identifierKeyspaceName:  identityAttributeSchema(false, "Keyspace of the resource, for resource types within a keyspace"),
+					grantIdentityIdentifier: identityAttributeSchema(false, "Table, function, role or mbean name of the resource, for resource types naming one"),
+					identifierPrivilege:     identityAttributeSchema(true, "Privilege granted"),
+				}
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			identifierPrivilege: {
 				Type:        schema.TypeString,
@@ -257,6 +273,13 @@ func resourceCassandraGrant() *schema.Resource {
 				},
 				ConflictsWith: []string{identifierFunctionName, identifierTableName, identifierRoleName, identifierMbeanName, identifierKeyspaceName},
 			},
+			identifierPrivileges: {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+				Description: "Privileges the grantee holds directly on the resource, as listed by LIST PERMISSIONS. ALL is listed as the individual privileges it stands for",
+			},
+			identifierConsistency: resourceConsistencySchema(),
 		},
 	}
 }
@@ -308,44 +331,144 @@ func parseData(d *schema.ResourceData) (*Grant, error) {
 	return &Grant{privilege, resourceType, grantee, keyspaceName, identifier}, nil
 }
 
-func resourceGrantExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+// resourceGrantImport accepts IDs of the form grantee|resource_type|keyspace|identifier|privilege,
+// keyspace and identifier are left empty for resource types without them, e.g.
+// app|table|shop|users|select or app|all keyspaces|||select. The identifier is the
+// table, function, role or mbean name.
+// Grants may also be imported by the attributes of their identity instead.
+func resourceGrantImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	identity, err := identityValues(d, identifierGrantee, identifierResourceType, identifierKeyspaceName, grantIdentityIdentifier, identifierPrivilege)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		d.SetId(strings.Join([]string{identity[identifierGrantee], identity[identifierResourceType], identity[identifierKeyspaceName], identity[grantIdentityIdentifier], identity[identifierPrivilege]}, "|"))
+	}
+
+	parts := strings.Split(d.Id(), "|")
+	if len(parts) != 5 || parts[0] == "" || parts[1] == "" || parts[4] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected grantee|resource_type|keyspace|identifier|privilege", d.Id())
+	}
+	grantee, resourceType, keyspaceName, identifier, privilege := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	d.Set(identifierGrantee, grantee)
+	d.Set(identifierResourceType, resourceType)
+	d.Set(identifierPrivilege, privilege)
+	if keyspaceName != "" {
+		d.Set(identifierKeyspaceName, keyspaceName)
+	}
+	if identifier != "" {
+		identifierKey := resourceTypeToIdentifier[resourceType]
+		if identifierKey == "" {
+			return nil, fmt.Errorf("resource type %s takes no identifier, leave it empty in %s", resourceType, d.Id())
+		}
+		d.Set(identifierKey, identifier)
+	}
 	grant, err := parseData(d)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	session, sessionCreationError := cluster.CreateSession()
-	if sessionCreationError != nil {
-		return false, sessionCreationError
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return nil, err
 	}
 	defer session.Close()
 
-	var buffer bytes.Buffer
-	tmpl, err := template.New("read_grant").Parse(templateReadGrant)
+	privileges, err := readGrantPrivileges(ctx, session, grant)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	if !grantListed(grant, privileges) {
+		return nil, fmt.Errorf("%s is not granted %s on %s", grantee, privilege, grant.permissionResource())
+	}
+
+	d.SetId(hash(fmt.Sprintf("%+v", grant)))
+	return []*schema.ResourceData{d}, nil
+}
+
+// permissionResource returns the resource of the grant as parsed from LIST PERMISSIONS.
+// Grants on mbeans are listed as mbean, the pattern tells them apart, and all tables
+// in a keyspace stands for the keyspace.
+func (g *Grant) permissionResource() permissionResource {
+	resource := permissionResource{ResourceType: g.ResourceType, Identifier: g.Identifier}
+	switch g.ResourceType {
+	case resourceMbeans:
+		resource.ResourceType = resourceMbean
+	case resourceAllTablesInKeyspace:
+		resource.ResourceType = resourceKeyspace
+	}
+	if g.Keyspace != "" {
+		resource.Keyspace = unquoteIdentifier(g.Keyspace)
+	}
+	return resource
+}
+
+// resourceClause renders the resource of the grant as used by GRANT and REVOKE, e.g.
+// all keyspaces or table "shop"."users".
+func (g *Grant) resourceClause() string {
+	resourceType := g.ResourceType
+	if resourceType == resourceAllTablesInKeyspace {
+		// CQL has no table wildcard, the permissions on a keyspace apply to all of its tables
+		resourceType = resourceKeyspace
+	}
+
+	var names []string
+	if g.Keyspace != "" {
+		names = append(names, fmt.Sprintf("%q", unquoteIdentifier(g.Keyspace)))
 	}
-	data := struct {
-		*Grant
-		SystemKeyspace string
-	}{
-		Grant:          grant,
-		SystemKeyspace: providerConfig.SystemKeyspaceName,
+	if g.Identifier != "" {
+		names = append(names, fmt.Sprintf("%q", g.Identifier))
 	}
-	if err := tmpl.Execute(&buffer, data); err != nil {
-		return false, err
+	if len(names) == 0 {
+		return resourceType
 	}
-	query := buffer.String()
+	return fmt.Sprintf("%s %s", resourceType, strings.Join(names, "."))
+}
+
+func (g *Grant) createStatement() string {
+	return fmt.Sprintf(`GRANT %s ON %s TO %q`, g.Privilege, g.resourceClause(), g.Grantee)
+}
+
+func (g *Grant) deleteStatement() string {
+	return fmt.Sprintf(`REVOKE %s ON %s FROM %q`, g.Privilege, g.resourceClause(), g.Grantee)
+}
 
-	iter := session.Query(query).Iter()
-	rowCount := iter.NumRows()
-	if err := iter.Close(); err != nil {
-		return false, err
+// readGrantPrivileges returns the privileges granted directly to the grantee on the
+// resource of grant, as listed by LIST ALL PERMISSIONS. None when the grantee does
+// not exist.
+func readGrantPrivileges(ctx context.Context, session *gocql.Session, grant *Grant) ([]string, error) {
+	rows, err := listPermissions(ctx, session, grant.Grantee, false)
+	if err != nil {
+		if strings.Contains(err.Error(), "doesn't exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	expected := grant.permissionResource()
+	var privileges []string
+	for _, row := range rows {
+		resource, _ := row["resource"].(string)
+		// resources the provider does not model cannot be the one of the grant
+		if parsed, err := parsePermissionResource(resource); err != nil || parsed != expected {
+			continue
+		}
+		permission, _ := row["permission"].(string)
+		privileges = append(privileges, strings.ToLower(permission))
 	}
-	return rowCount > 0, nil
+	return privileges, nil
+}
+
+// grantListed reports whether privileges hold the privilege of grant. ALL is listed
+// as the individual privileges it stands for, any of them left counts.
+func grantListed(grant *Grant, privileges []string) bool {
+	for _, privilege := range privileges {
+		if grant.Privilege == privilegeAll || privilege == grant.Privilege {
+			return true
+		}
+	}
+	return false
 }
 
 func resourceGrantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -356,22 +479,16 @@ func resourceGrantCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	session, sessionCreationError := cluster.CreateSession()
+	session, sessionCreationError := providerConfig.createSession(d)
 	if sessionCreationError != nil {
-		return diag.FromErr(sessionCreationError)
+		return errorDiagnostics(sessionCreationError, "", nil)
 	}
 	defer session.Close()
 
-	var buffer bytes.Buffer
-	if err := templateCreate.Execute(&buffer, grant); err != nil {
-		return diag.FromErr(err)
-	}
-	query := buffer.String()
+	query := grant.createStatement()
 	log.Printf("Executing query %v", query)
 	if err := session.Query(query).Exec(); err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, query, nil)
 	}
 	d.SetId(hash(fmt.Sprintf("%+v", grant)))
 	diags = append(diags, resourceGrantRead(ctx, d, meta)...)
@@ -379,18 +496,27 @@ func resourceGrantCreate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceGrantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	exists, err := resourceGrantExists(d, meta)
+	grant, err := parseData(d)
 	var diags diag.Diagnostics
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if !exists {
-		return diag.Errorf("Grant does not exist")
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreationError := providerConfig.createSession(d)
+	if sessionCreationError != nil {
+		return errorDiagnostics(sessionCreationError, "", nil)
 	}
+	defer session.Close()
 
-	grant, err := parseData(d)
+	privileges, err := readGrantPrivileges(ctx, session, grant)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, "", cty.GetAttrPath(identifierGrantee))
+	}
+	if !grantListed(grant, privileges) {
+		log.Printf("[WARN] Grant of %s on %s to '%s' no longer exists, removing it from the state", grant.Privilege, grant.permissionResource(), grant.Grantee)
+		d.SetId("")
+		return nil
 	}
 
 	d.Set(identifierResourceType, grant.ResourceType)
@@ -403,6 +529,16 @@ func resourceGrantRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		identifierName := resourceTypeToIdentifier[grant.ResourceType]
 		d.Set(identifierName, grant.Identifier)
 	}
+	d.Set(identifierPrivileges, privileges)
+	if err := setIdentity(d, map[string]string{
+		identifierGrantee:       grant.Grantee,
+		identifierResourceType:  grant.ResourceType,
+		identifierKeyspaceName:  grant.Keyspace,
+		grantIdentityIdentifier: grant.Identifier,
+		identifierPrivilege:     grant.Privilege,
+	}); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -413,26 +549,23 @@ func resourceGrantDelete(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.FromErr(err)
 	}
 
-	var buffer bytes.Buffer
-	if err := templateDelete.Execute(&buffer, grant); err != nil {
-		return diag.FromErr(err)
-	}
-
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-	session, err := cluster.CreateSession()
+	session, err := providerConfig.createSession(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, "", nil)
 	}
 	defer session.Close()
 
-	query := buffer.String()
+	query := grant.deleteStatement()
 	if err := session.Query(query).Exec(); err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, query, nil)
 	}
 	return diags
 }
 
 func resourceGrantUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Errorf("Updating of grants is not supported")
+	if d.HasChangeExcept(identifierConsistency) {
+		return diag.Errorf("Updating of grants is not supported")
+	}
+	return resourceGrantRead(ctx, d, meta)
 }
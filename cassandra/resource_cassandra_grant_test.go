@@ -1,8 +1,10 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -76,6 +78,27 @@ func testAccCassandraGrantExists(resourceKey string) resource.TestCheckFunc {
 	}
 }
 
+// resourceGrantExists reports whether the grant described by d is listed for its grantee.
+func resourceGrantExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	grant, err := parseData(d)
+	if err != nil {
+		return false, err
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreationError := providerConfig.createSession(d)
+	if sessionCreationError != nil {
+		return false, sessionCreationError
+	}
+	defer session.Close()
+
+	privileges, err := readGrantPrivileges(context.Background(), session, grant)
+	if err != nil {
+		return false, err
+	}
+	return grantListed(grant, privileges), nil
+}
+
 // testAccCassandraGrantDestroy verifies that the grant resource is removed.
 func testAccCassandraGrantDestroy(s *terraform.State) error {
 	pc := testAccProvider.Meta().(*ProviderConfig)
@@ -116,12 +139,71 @@ func TestAccCassandraGrant_basicCassandra(t *testing.T) {
 					testAccCassandraGrantExists("cassandra_grant.test"),
 					resource.TestCheckResourceAttr("cassandra_grant.test", "privilege", "select"),
 					resource.TestCheckResourceAttr("cassandra_grant.test", "grantee", "test_user"),
+					resource.TestCheckTypeSetElemAttr("cassandra_grant.test", "privileges.*", "select"),
 				),
 			},
+			{
+				// revoked outside of Terraform, the grant is planned again
+				PreConfig: func() {
+					session, err := testAccProvider.Meta().(*ProviderConfig).Cluster.CreateSession()
+					if err != nil {
+						t.Fatal(err)
+					}
+					defer session.Close()
+					if err := session.Query(`REVOKE select ON table "test_keyspace"."test_table" FROM "test_user"`).Exec(); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccCassandraGrantConfig("cassandra"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccCassandraGrantConfig("cassandra"),
+				Check:  testAccCassandraGrantExists("cassandra_grant.test"),
+			},
+			{
+				ResourceName:      "cassandra_grant.test",
+				ImportState:       true,
+				ImportStateId:     "test_user|table|test_keyspace|test_table|select",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+// TestAccCassandraGrant_allFunctionsInKeyspace tests a grant listed as <all functions in keyspace>
+// by LIST PERMISSIONS, which must be found again on refresh.
+func TestAccCassandraGrant_allFunctionsInKeyspace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          testAccPreCheckNoArgs,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraGrantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraGrantConfigAllFunctionsInKeyspace,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraGrantExists("cassandra_grant.functions"),
+					resource.TestCheckTypeSetElemAttr("cassandra_grant.functions", "privileges.*", "execute"),
+				),
+			},
+			{
+				Config:   testAccCassandraGrantConfigAllFunctionsInKeyspace,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraGrantConfigAllFunctionsInKeyspace = `
+resource "cassandra_grant" "functions" {
+  privilege     = "execute"
+  grantee       = "test_user"
+  resource_type = "all functions in keyspace"
+  keyspace_name = "test_keyspace"
+}
+`
+
 // TestAccCassandraGrant_basicScylla tests the cassandra_grant resource with provider mode "scylla".
 func TestAccCassandraGrant_basicScylla(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -140,3 +222,109 @@ func TestAccCassandraGrant_basicScylla(t *testing.T) {
 		},
 	})
 }
+
+func TestParseDataMaskingPrivileges(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceCassandraGrant().Schema, map[string]interface{}{
+		"privilege":     "unmask",
+		"grantee":       "support",
+		"resource_type": "table",
+		"keyspace_name": "shop",
+		"table_name":    "users",
+	})
+	grant, err := parseData(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `GRANT unmask ON table "shop"."users" TO "support"`
+	if statement := grant.createStatement(); statement != expected {
+		t.Errorf("expected %s, got %s", expected, statement)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceCassandraGrant().Schema, map[string]interface{}{
+		"privilege":     "select_masked",
+		"grantee":       "support",
+		"resource_type": "all functions",
+	})
+	if _, err := parseData(d); err == nil {
+		t.Error("expected select_masked to be rejected on all functions")
+	}
+}
+
+func TestGrantListed(t *testing.T) {
+	grant := &Grant{Privilege: "select", ResourceType: "table", Grantee: "app", Keyspace: `"Shop"`, Identifier: "users"}
+	if resource := grant.permissionResource(); resource.String() != "<table Shop.users>" {
+		t.Errorf("unexpected resource %s", resource)
+	}
+	mbeans := &Grant{Privilege: "execute", ResourceType: "mbeans", Grantee: "app", Identifier: "org.apache.cassandra.db:type=*"}
+	if parsed, err := parsePermissionResource("<mbean org.apache.cassandra.db:type=*>"); err != nil || parsed != mbeans.permissionResource() {
+		t.Errorf("expected <mbean org.apache.cassandra.db:type=*> to match %s, got %+v (%v)", mbeans.permissionResource(), parsed, err)
+	}
+	if !grantListed(grant, []string{"modify", "select"}) {
+		t.Error("expected select to be listed")
+	}
+	if grantListed(grant, []string{"modify"}) {
+		t.Error("expected select not to be listed")
+	}
+
+	grant.Privilege = "all"
+	if !grantListed(grant, []string{"modify"}) {
+		t.Error("expected all to be listed with any of its privileges")
+	}
+	if grantListed(grant, nil) {
+		t.Error("expected all not to be listed without privileges")
+	}
+}
+
+func TestGrantStatements(t *testing.T) {
+	for expected, attributes := range map[string]map[string]interface{}{
+		`GRANT select ON all keyspaces TO "app"`: {
+			"privilege":     "select",
+			"grantee":       "app",
+			"resource_type": "all keyspaces",
+		},
+		`GRANT modify ON keyspace "Shop" TO "app"`: {
+			"privilege":     "modify",
+			"grantee":       "app",
+			"resource_type": "all tables in keyspace",
+			"keyspace_name": `"Shop"`,
+		},
+		`GRANT execute ON all functions in keyspace "shop" TO "app"`: {
+			"privilege":     "execute",
+			"grantee":       "app",
+			"resource_type": "all functions in keyspace",
+			"keyspace_name": "shop",
+		},
+		`GRANT alter ON role "reader" TO "app"`: {
+			"privilege":     "alter",
+			"grantee":       "app",
+			"resource_type": "role",
+			"role_name":     "reader",
+		},
+	} {
+		grant, err := parseData(schema.TestResourceDataRaw(t, resourceCassandraGrant().Schema, attributes))
+		if err != nil {
+			t.Errorf("unexpected error for %s: %s", expected, err)
+			continue
+		}
+		if statement := grant.createStatement(); statement != expected {
+			t.Errorf("expected %s, got %s", expected, statement)
+		}
+		if revoke := strings.Replace(strings.Replace(expected, "GRANT", "REVOKE", 1), " TO ", " FROM ", 1); grant.deleteStatement() != revoke {
+			t.Errorf("expected %s, got %s", revoke, grant.deleteStatement())
+		}
+	}
+
+	grant := &Grant{Privilege: "select", ResourceType: "all tables in keyspace", Grantee: "app", Keyspace: "shop"}
+	if resource := grant.permissionResource(); resource.String() != "<keyspace shop>" {
+		t.Errorf("expected keyspace-wide table grants to be listed on the keyspace, got %s", resource)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCassandraGrant().Schema, map[string]interface{}{
+		"privilege":     "select",
+		"grantee":       "app",
+		"resource_type": "all tables in keyspace",
+	})
+	if _, err := parseData(d); err == nil {
+		t.Error("expected all tables in keyspace to require a keyspace")
+	}
+}
@@ -0,0 +1,120 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraIdentity() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Map a certificate identity to a role for mutual TLS authentication, Cassandra 5.0 and later",
+		CreateContext: resourceIdentityCreate,
+		ReadContext:   resourceIdentityRead,
+		UpdateContext: resourceIdentityUpdate,
+		DeleteContext: resourceIdentityDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"identity": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Identity extracted from the client certificate, e.g. a SPIFFE ID such as spiffe://example.com/app",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the role clients presenting the identity authenticate as",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+func resourceIdentityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	identity := d.Get("identity").(string)
+	role := d.Get("role").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	action := "ADD IDENTITY"
+	if providerConfig.AdoptExisting {
+		action = "ADD IDENTITY IF NOT EXISTS"
+	}
+	query := fmt.Sprintf(`%s %s TO ROLE %s`, action, quoteLiteral(identity), quoteLiteral(role))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(identity)
+	diags = append(diags, resourceIdentityRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceIdentityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	identity := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	var role string
+	query := fmt.Sprintf(`SELECT role FROM %s.identity_to_role WHERE identity = ?`, providerConfig.SystemKeyspaceName)
+	err = session.Query(query, identity).WithContext(ctx).Scan(&role)
+	if err == gocql.ErrNotFound {
+		log.Printf("[WARN] Identity '%s' no longer exists, removing it from the state", identity)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.Set("identity", identity)
+	d.Set("role", role)
+	return diags
+}
+
+// resourceIdentityUpdate only applies consistency changes, every other attribute forces a new identity.
+func resourceIdentityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceIdentityRead(ctx, d, meta)
+}
+
+func resourceIdentityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	identity := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	query := fmt.Sprintf(`DROP IDENTITY IF EXISTS %s`, quoteLiteral(identity))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
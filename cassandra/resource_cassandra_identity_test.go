@@ -0,0 +1,40 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraIdentity_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraIdentityConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_identity.app", "role", "app"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_identity.app",
+				ImportStateId:     "spiffe://example.com/app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraIdentityConfig = `
+resource "cassandra_role" "app" {
+  name     = "app"
+  password = "app-password-with-at-least-forty-characters!"
+}
+
+resource "cassandra_identity" "app" {
+  identity = "spiffe://example.com/app"
+  role     = cassandra_role.app.name
+}
+`
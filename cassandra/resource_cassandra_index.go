@@ -0,0 +1,236 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// indexTargets are the functions selecting what part of a collection column is indexed.
+var indexTargets = []string{"keys", "values", "entries", "full"}
+
+var indexTargetRegex = regexp.MustCompile(`^(keys|values|entries|full)\((.+)\)$`)
+
+func resourceCassandraIndex() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage secondary indexes",
+		CreateContext: resourceIndexCreate,
+		ReadContext:   resourceIndexRead,
+		UpdateContext: resourceIndexUpdate,
+		DeleteContext: resourceIndexDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIndexImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the index, unique within the keyspace",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\w{1,256}$`), "must contain between 1 and 256 alphanumeric characters or underscores"),
+			},
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace of the indexed table, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"table": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the indexed table",
+			},
+			"column": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the indexed column",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "Part of a collection column to index, one of keys, values, entries or full. The server indexes the values of non frozen collections by default",
+				ValidateFunc: validation.StringInSlice(indexTargets, false),
+			},
+			"class": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Class implementing a custom index, e.g. StorageAttachedIndex or org.apache.cassandra.index.sasi.SASIIndex. Creates a CUSTOM INDEX",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"options": {
+				Type:         schema.TypeMap,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Options passed to the custom index class",
+				RequiredWith: []string{"class"},
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// resourceIndexImport accepts IDs of the form keyspace.index.
+func resourceIndexImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keyspaceName, name, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.index", d.Id())
+	}
+
+	d.SetId(name)
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	return []*schema.ResourceData{d}, nil
+}
+
+func generateCreateIndexQueryString(keyspace string, table string, name string, ifNotExists bool, column string, target string, class string, options map[string]interface{}) string {
+	indexed := fmt.Sprintf("%q", column)
+	if target != "" {
+		indexed = fmt.Sprintf("%s(%s)", target, indexed)
+	}
+
+	action := "CREATE INDEX"
+	if class != "" {
+		action = "CREATE CUSTOM INDEX"
+	}
+	if ifNotExists {
+		action += " IF NOT EXISTS"
+	}
+	query := fmt.Sprintf(`%s %q ON %q.%q (%s)`, action, name, unquoteIdentifier(keyspace), table, indexed)
+	if class != "" {
+		query += fmt.Sprintf(" USING '%s'", strings.ReplaceAll(class, "'", "''"))
+		if len(options) > 0 {
+			query += fmt.Sprintf(" WITH OPTIONS = %s", cqlMapLiteral(options))
+		}
+	}
+	return query
+}
+
+// customIndexOptions returns the options of a custom index as recorded in
+// system_schema.indexes, without the class name and target the server adds.
+func customIndexOptions(options map[string]string) map[string]string {
+	custom := make(map[string]string, len(options))
+	for key, value := range options {
+		if key != "class_name" && key != "target" {
+			custom[key] = value
+		}
+	}
+	return custom
+}
+
+// parseIndexTarget splits the target option of system_schema.indexes, e.g.
+// keys(tags) or "Email", into the target function and the column name.
+func parseIndexTarget(target string) (string, string) {
+	if match := indexTargetRegex.FindStringSubmatch(target); match != nil {
+		return match[1], unquoteIdentifier(match[2])
+	}
+	return "", unquoteIdentifier(target)
+}
+
+func resourceIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	query := generateCreateIndexQueryString(keyspaceName, table, name, providerConfig.AdoptExisting, d.Get("column").(string), d.Get("target").(string), d.Get("class").(string), d.Get("options").(map[string]interface{}))
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Creating index '%s' on '%s' in '%s'", name, table, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(name)
+	diags = append(diags, resourceIndexRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	var table, kind string
+	var options map[string]string
+	err := session.Query(`SELECT table_name, kind, options FROM system_schema.indexes WHERE keyspace_name = ? AND index_name = ?`, unquoteIdentifier(keyspaceName), name).WithContext(ctx).Scan(&table, &kind, &options)
+	if err == gocql.ErrNotFound {
+		log.Printf("[WARN] Index '%s' no longer exists in '%s', removing it from the state", name, keyspaceName)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+
+	target, column := parseIndexTarget(options["target"])
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("table", table)
+	d.Set("column", column)
+	d.Set("target", target)
+	if kind == "CUSTOM" {
+		class := options["class_name"]
+		// the server records the fully qualified name of the built in classes
+		if configured := d.Get("class").(string); configured != "" && shortStrategyClass(class) == shortStrategyClass(configured) {
+			class = configured
+		}
+		d.Set("class", class)
+		d.Set("options", customIndexOptions(options))
+	} else {
+		d.Set("class", "")
+		d.Set("options", map[string]string{})
+	}
+	return diags
+}
+
+// resourceIndexUpdate only applies consistency changes, every other attribute forces a new index.
+func resourceIndexUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceIndexRead(ctx, d, meta)
+}
+
+func resourceIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Deleting index '%s' in '%s'", name, keyspaceName)
+	query := fmt.Sprintf(`DROP INDEX IF EXISTS %q.%q`, unquoteIdentifier(keyspaceName), name)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
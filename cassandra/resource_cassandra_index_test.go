@@ -0,0 +1,113 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGenerateCreateIndexQueryString(t *testing.T) {
+	query := generateCreateIndexQueryString("shop", "orders", "orders_by_email", false, "email", "", "", nil)
+	expected := `CREATE INDEX "orders_by_email" ON "shop"."orders" ("email")`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateCreateIndexQueryString(`"Shop"`, "orders", "orders_by_tag", true, "tags", "keys", "", nil)
+	expected = `CREATE INDEX IF NOT EXISTS "orders_by_tag" ON "Shop"."orders" (keys("tags"))`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateCreateIndexQueryString("shop", "orders", "orders_by_name", false, "name", "", "StorageAttachedIndex", map[string]interface{}{
+		"case_sensitive": "false",
+		"normalize":      "true",
+	})
+	expected = `CREATE CUSTOM INDEX "orders_by_name" ON "shop"."orders" ("name") USING 'StorageAttachedIndex' WITH OPTIONS = { 'case_sensitive' : 'false', 'normalize' : 'true' }`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestParseIndexTarget(t *testing.T) {
+	for raw, expected := range map[string][2]string{
+		"email":           {"", "email"},
+		`"Email"`:         {"", "Email"},
+		"keys(tags)":      {"keys", "tags"},
+		`entries("Tags")`: {"entries", "Tags"},
+	} {
+		target, column := parseIndexTarget(raw)
+		if target != expected[0] || column != expected[1] {
+			t.Errorf("expected %v for %s, got [%s %s]", expected, raw, target, column)
+		}
+	}
+}
+
+func TestAccCassandraIndex_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraIndexConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_index.by_email", "table", "users"),
+					resource.TestCheckResourceAttr("cassandra_index.by_email", "column", "email"),
+					resource.TestCheckResourceAttr("cassandra_index.by_tag", "target", "keys"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_index.by_email",
+				ImportStateId:     "index_test.users_by_email",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraIndexConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "index_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_table" "users" {
+  name           = "users"
+  keyspace       = cassandra_keyspace.keyspace.name
+  partition_keys = ["id"]
+
+  column {
+    name = "id"
+    type = "uuid"
+  }
+
+  column {
+    name = "email"
+    type = "text"
+  }
+
+  column {
+    name = "tags"
+    type = "map<text, text>"
+  }
+}
+
+resource "cassandra_index" "by_email" {
+  name     = "users_by_email"
+  keyspace = cassandra_keyspace.keyspace.name
+  table    = cassandra_table.users.name
+  column   = "email"
+}
+
+resource "cassandra_index" "by_tag" {
+  name     = "users_by_tag"
+  keyspace = cassandra_keyspace.keyspace.name
+  table    = cassandra_table.users.name
+  column   = "tags"
+  target   = "keys"
+}
+`
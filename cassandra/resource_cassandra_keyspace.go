@@ -6,18 +6,19 @@ import (
 	"log"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gocql/gocql"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	keyspaceLiteralPattern = `^[a-zA-Z0-9][a-zA-Z0-9_]{0,48}$`
+	keyspaceLiteralPattern = `^([a-zA-Z0-9][a-zA-Z0-9_]{0,48}|"[a-zA-Z0-9][a-zA-Z0-9_]{0,47}")$`
 )
 
 var (
@@ -26,6 +27,12 @@ var (
 		true:  "CREATE",
 		false: "ALTER",
 	}
+	// replication strategies placing replicas without any options
+	strategiesWithoutOptions = map[string]bool{
+		"EverywhereStrategy":   true,
+		"LocalStrategy":        true,
+		"SingleRegionStrategy": true,
+	}
 )
 
 func resourceCassandraKeyspace() *schema.Resource {
@@ -35,15 +42,27 @@ func resourceCassandraKeyspace() *schema.Resource {
 		ReadContext:   resourceKeyspaceRead,
 		UpdateContext: resourceKeyspaceUpdate,
 		DeleteContext: resourceKeyspaceDelete,
+		CustomizeDiff: customdiff.All(
+			validateKeyspaceStrategyOptions,
+			guardKeyspaceReplicationChange,
+			validateKeyspaceDatacenters,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: schema.ImportStatePassthroughWithIdentity("name"),
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"name": identityAttributeSchema(true, "Name of the keyspace"),
+				}
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Name of keyspace",
+				Description: "Name of keyspace, quote it (e.g. \"MyKeyspace\") for case sensitive names",
 				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
 					name := i.(string)
 					if !keyspaceRegex.MatchString(name) {
@@ -56,7 +75,7 @@ func resourceCassandraKeyspace() *schema.Resource {
 							},
 						}
 					}
-					if name == "system" {
+					if unquoteIdentifier(name) == "system" {
 						return diag.Diagnostics{
 							{
 								Severity:      diag.Error,
@@ -72,26 +91,16 @@ func resourceCassandraKeyspace() *schema.Resource {
 			"replication_strategy": {
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "Keyspace replication strategy - must be one of SimpleStrategy or NetworkTopologyStrategy",
-				ValidateFunc: validation.StringInSlice([]string{"SimpleStrategy", "NetworkTopologyStrategy", "SingleRegionStrategy"}, false),
+				Description:  "Keyspace replication strategy - must be one of SimpleStrategy, NetworkTopologyStrategy, SingleRegionStrategy, EverywhereStrategy or LocalStrategy",
+				ValidateFunc: validation.StringInSlice([]string{"SimpleStrategy", "NetworkTopologyStrategy", "SingleRegionStrategy", "EverywhereStrategy", "LocalStrategy"}, false),
 			},
 			"strategy_options": {
 				Type:        schema.TypeMap,
-				Required:    true,
-				Description: "strategy options used with replication strategy",
+				Optional:    true,
+				Description: "strategy options used with replication strategy, e.g. the replication factor of each datacenter for NetworkTopologyStrategy. Cassandra 4.0 and later accept replication_factor with NetworkTopologyStrategy as a default for every datacenter",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				StateFunc: func(v interface{}) string {
-					strategyOptions := v.(map[string]interface{})
-					keys := make([]string, 0, len(strategyOptions))
-					for key, value := range strategyOptions {
-						strValue := value.(string)
-						keys = append(keys, fmt.Sprintf("%q=%q", key, strValue))
-					}
-					sort.Strings(keys)
-					return hash(strings.Join(keys, ", "))
-				},
 			},
 			"durable_writes": {
 				Type:        schema.TypeBool,
@@ -99,50 +108,328 @@ func resourceCassandraKeyspace() *schema.Resource {
 				Description: "Enable or disable durable writes - disabling is not recommended",
 				Default:     true,
 			},
+			"effective_strategy_options": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "strategy options as normalized by the server, e.g. with the replication_factor shorthand expanded to every datacenter",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Sorted names of the tables the keyspace contains",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tablets": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "Scylla tablets settings of the keyspace. Scylla only, defaults to the cluster configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether the keyspace uses tablets instead of vnodes",
+						},
+						"initial": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							Description:  "Initial number of tablets of each table, 0 lets Scylla choose",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to drop the keyspace while enabled",
+			},
+			"allow_drop_non_empty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow dropping the keyspace while it still contains tables",
+			},
+			"allow_strategy_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow changing the replication strategy or replication factors of the existing keyspace. Such changes require a full repair of the keyspace",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create the keyspace with IF NOT EXISTS and take over an existing keyspace of the same name. Its actual replication settings are read into the state, so the next plan shows any difference to the configuration",
+			},
+			"consistency": resourceConsistencySchema(),
 		},
 	}
 }
 
-func generateCreateOrUpdateKeyspaceQueryString(name string, create bool, replicationStrategy string, strategyOptions map[string]interface{}, durableWrites bool) (string, error) {
-	if len(strategyOptions) == 0 {
+func generateCreateOrUpdateKeyspaceQueryString(name string, create bool, ifNotExists bool, replicationStrategy string, strategyOptions map[string]interface{}, durableWrites bool) (string, error) {
+	if len(strategyOptions) == 0 && !strategiesWithoutOptions[replicationStrategy] {
 		return "", fmt.Errorf("must specify strategy options - see https://docs.datastax.com/en/cql/3.3/cql/cql_reference/cqlCreateKeyspace.html")
 	}
 
-	query := fmt.Sprintf(`%s KEYSPACE %s WITH REPLICATION = { 'class' : '%s'`, boolToAction[create], name, replicationStrategy)
-	for key, value := range strategyOptions {
-		query += fmt.Sprintf(`, '%s' : '%s'`, key, value.(string))
+	action := boolToAction[create]
+	if create && ifNotExists {
+		action += " KEYSPACE IF NOT EXISTS"
+	} else {
+		action += " KEYSPACE"
+	}
+
+	query := fmt.Sprintf(`%s %s WITH REPLICATION = { 'class' : '%s'`, action, name, replicationStrategy)
+	keys := make([]string, 0, len(strategyOptions))
+	for key := range strategyOptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query += fmt.Sprintf(`, '%s' : '%s'`, key, strategyOptions[key].(string))
 	}
 	query += fmt.Sprintf(` } AND DURABLE_WRITES = %t`, durableWrites)
 	log.Println("query", query)
 	return query, nil
 }
 
+// shortStrategyClass strips the package from a replication strategy class, e.g.
+// org.apache.cassandra.locator.EverywhereStrategy becomes EverywhereStrategy.
+func shortStrategyClass(class string) string {
+	return class[strings.LastIndex(class, ".")+1:]
+}
+
+// collapseReplicationFactor folds the per-datacenter replication factors the
+// server expanded from the NetworkTopologyStrategy replication_factor shorthand
+// back into it, so configurations using the shorthand do not show perpetual diffs.
+// Datacenters deviating from the shorthand are kept. The shorthand cannot be mixed
+// with datacenters, see checkStrategyOptions.
+func collapseReplicationFactor(configured map[string]interface{}, actual map[string]string) map[string]string {
+	rawReplicationFactor, ok := configured["replication_factor"]
+	if !ok {
+		return actual
+	}
+	replicationFactor := rawReplicationFactor.(string)
+
+	collapsed := map[string]string{
+		"replication_factor": replicationFactor,
+	}
+	for datacenter, value := range actual {
+		if value != replicationFactor {
+			collapsed[datacenter] = value
+		}
+	}
+	return collapsed
+}
+
+// generateTabletsClause returns the Scylla TABLETS option of the keyspace, if configured.
+func generateTabletsClause(tablets []interface{}) string {
+	if len(tablets) == 0 || tablets[0] == nil {
+		return ""
+	}
+	options := tablets[0].(map[string]interface{})
+	if !options["enabled"].(bool) {
+		return ` AND TABLETS = { 'enabled' : false }`
+	}
+	if initial := options["initial"].(int); initial > 0 {
+		return fmt.Sprintf(` AND TABLETS = { 'enabled' : true, 'initial' : %d }`, initial)
+	}
+	return ` AND TABLETS = { 'enabled' : true }`
+}
+
+// readTablets reads the tablets settings of a keyspace from Scylla. Keyspaces
+// using vnodes have no initial_tablets.
+func readTablets(session *gocql.Session, keyspace string) ([]interface{}, error) {
+	var initialTablets *int
+	err := session.Query(`SELECT initial_tablets FROM system_schema.scylla_keyspaces WHERE keyspace_name = ?`, keyspace).Scan(&initialTablets)
+	if err == gocql.ErrNotFound {
+		initialTablets = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	tablets := map[string]interface{}{
+		"enabled": initialTablets != nil,
+		"initial": 0,
+	}
+	if initialTablets != nil {
+		tablets["initial"] = *initialTablets
+	}
+	return []interface{}{tablets}, nil
+}
+
+// readKeyspaceTables returns the sorted names of the tables in keyspace.
+func readKeyspaceTables(session *gocql.Session, keyspace string) ([]string, error) {
+	iter := session.Query(`SELECT table_name FROM system_schema.tables WHERE keyspace_name = ?`, keyspace).Iter()
+	var (
+		tables []string
+		table  string
+	)
+	for iter.Scan(&table) {
+		tables = append(tables, table)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	sort.Strings(tables)
+	return tables, nil
+}
+
+// readDatacenters returns the names of the datacenters the cluster nodes belong to.
+func readDatacenters(session *gocql.Session) (map[string]bool, error) {
+	datacenters := make(map[string]bool)
+	for _, query := range []string{`SELECT data_center FROM system.local`, `SELECT data_center FROM system.peers`} {
+		iter := session.Query(query).Iter()
+		var datacenter string
+		for iter.Scan(&datacenter) {
+			datacenters[datacenter] = true
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return datacenters, nil
+}
+
+// replicationChangeWarning describes the repair required after the replication of keyspace changed.
+func replicationChangeWarning(keyspace string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Keyspace replication changed, a repair is required",
+		Detail: fmt.Sprintf("The replication of keyspace %s changed. Existing data is not streamed to the new replicas, "+
+			"run a full repair (nodetool repair -full %s) on every node before relying on the new replication, "+
+			"and nodetool cleanup on nodes that lost replicas.", keyspace, unquoteIdentifier(keyspace)),
+	}
+}
+
+// validateKeyspaceStrategyOptions catches invalid strategy_options at plan time
+// rather than failing on the server during apply.
+func validateKeyspaceStrategyOptions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("strategy_options") || !d.NewValueKnown("replication_strategy") {
+		return nil
+	}
+	replicationStrategy := d.Get("replication_strategy").(string)
+	strategyOptions := d.Get("strategy_options").(map[string]interface{})
+	return checkStrategyOptions(replicationStrategy, strategyOptions)
+}
+
+func checkStrategyOptions(replicationStrategy string, strategyOptions map[string]interface{}) error {
+	for key, value := range strategyOptions {
+		replicationFactor, err := strconv.Atoi(value.(string))
+		// a datacenter may be given no replicas, the default replication factor must be positive
+		if err != nil || replicationFactor < 0 || (replicationFactor == 0 && key == "replication_factor") {
+			return fmt.Errorf("strategy_options.%s must be a non-negative integer (replication_factor must be positive), got %q", key, value.(string))
+		}
+	}
+
+	switch replicationStrategy {
+	case "SimpleStrategy":
+		for key := range strategyOptions {
+			if key != "replication_factor" {
+				return fmt.Errorf("SimpleStrategy only supports the replication_factor option, got %s", key)
+			}
+		}
+	case "NetworkTopologyStrategy":
+		if _, ok := strategyOptions["replication_factor"]; ok && len(strategyOptions) > 1 {
+			return fmt.Errorf("NetworkTopologyStrategy takes either replication_factor or the replication factor of each datacenter, not both")
+		}
+	default:
+		if strategiesWithoutOptions[replicationStrategy] && len(strategyOptions) > 0 {
+			return fmt.Errorf("%s does not take strategy_options", replicationStrategy)
+		}
+	}
+	return nil
+}
+
+// guardKeyspaceReplicationChange refuses changes of the replication class or
+// factors of an existing keyspace unless allow_strategy_change is set, they
+// require repairs and can leave data unavailable until then.
+func guardKeyspaceReplicationChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.Get("allow_strategy_change").(bool) {
+		return nil
+	}
+	if d.HasChange("replication_strategy") || d.HasChange("strategy_options") {
+		return fmt.Errorf("changing the replication of keyspace %s requires a repair of the keyspace, set allow_strategy_change = true to apply it", d.Get("name").(string))
+	}
+	return nil
+}
+
+// validateKeyspaceDatacenters checks the NetworkTopologyStrategy options name
+// datacenters that exist, a typo silently leaves the keyspace unreplicated there.
+func validateKeyspaceDatacenters(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("replication_strategy").(string) != "NetworkTopologyStrategy" || !d.NewValueKnown("strategy_options") {
+		return nil
+	}
+	if !d.HasChange("strategy_options") && !d.HasChange("replication_strategy") {
+		return nil
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(nil)
+	if err != nil {
+		// the cluster may not exist yet when it is created in the same apply
+		log.Printf("[WARN] Unable to validate the datacenters of keyspace %s: %s", d.Get("name").(string), err)
+		return nil
+	}
+	defer session.Close()
+
+	datacenters, err := readDatacenters(session)
+	if err != nil || len(datacenters) == 0 {
+		log.Printf("[WARN] Unable to read the datacenters of the cluster: %v", err)
+		return nil
+	}
+
+	var unknown []string
+	for key := range d.Get("strategy_options").(map[string]interface{}) {
+		if key != "replication_factor" && !datacenters[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		names := make([]string, 0, len(datacenters))
+		for name := range datacenters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("strategy_options reference unknown datacenters %s, the cluster has %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+	}
+	return nil
+}
+
 func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	replicationStrategy := d.Get("replication_strategy").(string)
 	strategyOptions := d.Get("strategy_options").(map[string]interface{})
 	durableWrites := d.Get("durable_writes").(bool)
 	var diags diag.Diagnostics
+	providerConfig := meta.(*ProviderConfig)
 
-	query, err := generateCreateOrUpdateKeyspaceQueryString(name, true, replicationStrategy, strategyOptions, durableWrites)
+	query, err := generateCreateOrUpdateKeyspaceQueryString(name, true, providerConfig.AdoptExisting || d.Get("adopt_existing").(bool), replicationStrategy, strategyOptions, durableWrites)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	query += generateTabletsClause(d.Get("tablets").([]interface{}))
 
-	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	err = session.Query(query).Exec()
+	err = providerConfig.executeDDL(ctx, session, query)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, query, nil)
 	}
 
 	d.SetId(name)
@@ -153,57 +440,104 @@ func resourceKeyspaceCreate(ctx context.Context, d *schema.ResourceData, meta in
 func resourceKeyspaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Id()
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
 	var diags diag.Diagnostics
 
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	keyspaceMetadata, err := session.KeyspaceMetadata(name)
+	keyspaceMetadata, err := session.KeyspaceMetadata(unquoteIdentifier(name))
 	if err == gocql.ErrKeyspaceDoesNotExist {
 		d.SetId("")
 		return nil
 	} else if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, "", nil)
 	}
 
 	strategyOptions := make(map[string]string)
 	for key, value := range keyspaceMetadata.StrategyOptions {
-		strategyOptions[key] = value.(string)
+		strategyOptions[key] = fmt.Sprint(value)
+	}
+
+	tables := make([]string, 0, len(keyspaceMetadata.Tables))
+	for table := range keyspaceMetadata.Tables {
+		tables = append(tables, table)
 	}
+	sort.Strings(tables)
 
-	strategyClass := strings.TrimPrefix(keyspaceMetadata.StrategyClass, "org.apache.cassandra.locator.")
+	strategyClass := shortStrategyClass(keyspaceMetadata.StrategyClass)
+	d.Set("effective_strategy_options", strategyOptions)
+	if strategyClass == "NetworkTopologyStrategy" {
+		strategyOptions = collapseReplicationFactor(d.Get("strategy_options").(map[string]interface{}), strategyOptions)
+	}
+	if err := setIdentity(d, map[string]string{"name": name}); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set("name", name)
+	d.Set("tables", tables)
 	d.Set("replication_strategy", strategyClass)
 	d.Set("durable_writes", keyspaceMetadata.DurableWrites)
 	d.Set("strategy_options", strategyOptions)
+	if providerConfig.Mode == modeScylla {
+		if tablets, err := readTablets(session, unquoteIdentifier(name)); err != nil {
+			log.Printf("[WARN] Unable to read the tablets settings of keyspace %s: %s", name, err)
+		} else {
+			d.Set("tablets", tablets)
+		}
+	}
+	// not stored on the server, keep the configured values (defaults on import)
+	d.Set("deletion_protection", d.Get("deletion_protection").(bool))
+	d.Set("allow_drop_non_empty", d.Get("allow_drop_non_empty").(bool))
+	d.Set("adopt_existing", d.Get("adopt_existing").(bool))
+	d.Set("allow_strategy_change", d.Get("allow_strategy_change").(bool))
 	return diags
 }
 
 func resourceKeyspaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
 	var diags diag.Diagnostics
 
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       "Keyspace is protected from deletion",
+				Detail:        fmt.Sprintf("keyspace %s has deletion_protection enabled, disable it and apply before destroying the keyspace", name),
+				AttributePath: cty.GetAttrPath("deletion_protection"),
+			},
+		}
+	}
+
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	err := session.Query(fmt.Sprintf(`DROP KEYSPACE %s`, name)).Exec()
+	if !d.Get("allow_drop_non_empty").(bool) {
+		tables, err := readKeyspaceTables(session, unquoteIdentifier(name))
+		if err != nil {
+			return errorDiagnostics(err, "", nil)
+		}
+		if len(tables) > 0 {
+			return diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "Keyspace is not empty",
+					Detail:        fmt.Sprintf("keyspace %s still contains the tables %s, drop them first or set allow_drop_non_empty", name, strings.Join(tables, ", ")),
+					AttributePath: cty.GetAttrPath("allow_drop_non_empty"),
+				},
+			}
+		}
+	}
+
+	query := fmt.Sprintf(`DROP KEYSPACE IF EXISTS %s`, name)
+	err := providerConfig.executeDDL(ctx, session, query)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, query, nil)
 	}
 	return diags
 }
@@ -215,25 +549,28 @@ func resourceKeyspaceUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	durableWrites := d.Get("durable_writes").(bool)
 	var diags diag.Diagnostics
 
-	query, err := generateCreateOrUpdateKeyspaceQueryString(name, false, replicationStrategy, strategyOptions, durableWrites)
+	if !d.HasChanges("replication_strategy", "strategy_options", "durable_writes") {
+		return resourceKeyspaceRead(ctx, d, meta)
+	}
+
+	query, err := generateCreateOrUpdateKeyspaceQueryString(name, false, false, replicationStrategy, strategyOptions, durableWrites)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	err = session.Query(query).Exec()
+	err = providerConfig.executeDDL(ctx, session, query)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, query, nil)
+	}
+	if d.HasChanges("replication_strategy", "strategy_options") {
+		diags = append(diags, replicationChangeWarning(name))
 	}
 	diags = append(diags, resourceKeyspaceRead(ctx, d, meta)...)
 	return diags
@@ -2,6 +2,7 @@ package cassandra
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -53,6 +54,84 @@ func TestAccCassandraKeyspace_broken(t *testing.T) {
 	})
 }
 
+func TestGenerateCreateOrUpdateKeyspaceQueryString(t *testing.T) {
+	strategyOptions := map[string]interface{}{
+		"dc2": "5",
+		"dc1": "3",
+	}
+	query, err := generateCreateOrUpdateKeyspaceQueryString("some_keyspace", false, false, "NetworkTopologyStrategy", strategyOptions, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `ALTER KEYSPACE some_keyspace WITH REPLICATION = { 'class' : 'NetworkTopologyStrategy', 'dc1' : '3', 'dc2' : '5' } AND DURABLE_WRITES = true`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+}
+
+func TestGenerateCreateOrUpdateKeyspaceQueryStringWithoutOptions(t *testing.T) {
+	query, err := generateCreateOrUpdateKeyspaceQueryString("some_keyspace", true, false, "EverywhereStrategy", map[string]interface{}{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `CREATE KEYSPACE some_keyspace WITH REPLICATION = { 'class' : 'EverywhereStrategy' } AND DURABLE_WRITES = true`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	if _, err := generateCreateOrUpdateKeyspaceQueryString("some_keyspace", true, false, "SimpleStrategy", map[string]interface{}{}, true); err == nil {
+		t.Error("expected an error for SimpleStrategy without options")
+	}
+}
+
+func TestCheckStrategyOptions(t *testing.T) {
+	valid := []struct {
+		strategy string
+		options  map[string]interface{}
+	}{
+		{"SimpleStrategy", map[string]interface{}{"replication_factor": "3"}},
+		{"NetworkTopologyStrategy", map[string]interface{}{"dc1": "3", "dc2": "0"}},
+		{"NetworkTopologyStrategy", map[string]interface{}{"replication_factor": "3"}},
+		{"EverywhereStrategy", map[string]interface{}{}},
+	}
+	for _, c := range valid {
+		if err := checkStrategyOptions(c.strategy, c.options); err != nil {
+			t.Errorf("expected %s %v to be valid, got %s", c.strategy, c.options, err)
+		}
+	}
+
+	invalid := []struct {
+		strategy string
+		options  map[string]interface{}
+	}{
+		{"SimpleStrategy", map[string]interface{}{"replication_factor": "three"}},
+		{"SimpleStrategy", map[string]interface{}{"replication_factor": "0"}},
+		{"SimpleStrategy", map[string]interface{}{"dc1": "3"}},
+		{"NetworkTopologyStrategy", map[string]interface{}{"replication_factor": "3", "dc1": "3"}},
+		{"LocalStrategy", map[string]interface{}{"replication_factor": "1"}},
+	}
+	for _, c := range invalid {
+		if err := checkStrategyOptions(c.strategy, c.options); err == nil {
+			t.Errorf("expected %s %v to be invalid", c.strategy, c.options)
+		}
+	}
+}
+
+func TestCollapseReplicationFactor(t *testing.T) {
+	actual := map[string]string{"dc1": "3", "dc2": "3", "dc3": "1"}
+
+	collapsed := collapseReplicationFactor(map[string]interface{}{"replication_factor": "3"}, actual)
+	expected := map[string]string{"replication_factor": "3", "dc3": "1"}
+	if !reflect.DeepEqual(collapsed, expected) {
+		t.Errorf("expected %v, got %v", expected, collapsed)
+	}
+
+	explicit := map[string]interface{}{"dc1": "3", "dc2": "3", "dc3": "1"}
+	if collapsed := collapseReplicationFactor(explicit, actual); !reflect.DeepEqual(collapsed, actual) {
+		t.Errorf("expected %v, got %v", actual, collapsed)
+	}
+}
+
 func testAccCassandraKeyspaceConfigBasic(keyspace string) string {
 	return fmt.Sprintf(`
 resource "cassandra_keyspace" "keyspace" {
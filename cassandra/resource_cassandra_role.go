@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -19,8 +23,16 @@ func resourceCassandraRole() *schema.Resource {
 		ReadContext:   resourceRoleRead,
 		UpdateContext: resourceRoleUpdate,
 		DeleteContext: resourceRoleDelete,
+		CustomizeDiff: customdiff.All(validateRolePasswordSource, validatePasswordPolicy, protectOwnRole, requireSuperuserOptIn),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceRoleImport,
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"name": identityAttributeSchema(true, "Name of the role"),
+				}
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -34,7 +46,7 @@ func resourceCassandraRole() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Allow role to create and manage other roles",
+				Description: "Allow role to create and manage other roles. Requires allow_superuser in the provider",
 			},
 			"login": {
 				Type:        schema.TypeBool,
@@ -43,21 +55,208 @@ func resourceCassandraRole() *schema.Resource {
 				Description: "Enable login for the role",
 			},
 			"password": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Sensitive:    true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				Description:   "Password of the role, must meet the password policy of the provider and must not contain single quotes. Changes are applied with ALTER ROLE. Stored in the state, keep the state encrypted. Holds the generated password with generate_password",
+				ValidateFunc:  validation.All(validation.StringLenBetween(1, 512), validation.StringDoesNotContainAny("'")),
+				ConflictsWith: []string{"hashed_password", "generate_password", "password_wo", "external_password"},
+			},
+			"password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				Description:   "Write-only password of the role that is never stored in the state or plan, requires Terraform 1.11 or later. Changing it alone does not update the role, bump password_version to rotate it",
+				ValidateFunc:  validation.All(validation.StringLenBetween(1, 512), validation.StringDoesNotContainAny("'")),
+				ConflictsWith: []string{"password", "hashed_password", "generate_password", "external_password"},
+				RequiredWith:  []string{"password_version"},
+			},
+			"password_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Version of password_wo, changing it sends the current password_wo to the cluster",
+				RequiredWith: []string{"password_wo"},
+			},
+			"hashed_password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "bcrypt hash of the password of the role, set with WITH HASHED PASSWORD so the plaintext never reaches Terraform. Requires Cassandra 4.1 or later. Changes to the hash made outside of Terraform are detected",
+				ValidateFunc:  validation.StringMatch(bcryptHashRegex, "must be a bcrypt hash, e.g. $2a$10$..."),
+				ConflictsWith: []string{"password", "generate_password", "password_wo", "external_password"},
+			},
+			"generate_password": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ForceNew:      true,
+				Description:   "Generate a random password meeting the password policy of the provider when the role is created. The password is exposed by the password attribute",
+				ConflictsWith: []string{"password", "hashed_password", "password_wo", "external_password"},
+			},
+			"external_password": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Leave the password of the role to an external system, e.g. Vault or an operator rotating it. No password is set and changes to it are not detected",
+				ConflictsWith: []string{"password", "hashed_password", "password_wo", "generate_password"},
+			},
+			"generated_password_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      32,
 				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(40, 512),
+				Description:  "Length of the generated password, raised to the password_min_length of the provider when lower",
+				ValidateFunc: validation.IntBetween(8, 512),
+			},
+			"generated_password_special": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "Include special characters in the generated password, besides letters and digits",
 			},
+			"options": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Custom options of the role passed to the authenticator with WITH OPTIONS, e.g. DSE specific role settings. Cassandra's PasswordAuthenticator rejects them",
+			},
+			"access_to_datacenters": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Datacenters the role may access, with CassandraNetworkAuthorizer on Cassandra 4.0 and later. The role may access all datacenters when not set",
+			},
+			"consistency": resourceConsistencySchema(),
 		},
 	}
 }
 
-func readRole(session *gocql.Session, name string, systemKeyspace string) (string, bool, bool, string, error) {
+// protectOwnRole refuses to revoke login or superuser status from the role the
+// provider authenticates as, unless allow_self_lockout is set.
+func protectOwnRole(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	providerConfig, ok := meta.(*ProviderConfig)
+	if !ok || providerConfig.AllowSelfLockout || d.Id() == "" || d.Id() != providerConfig.Username {
+		return nil
+	}
+	if old, new := d.GetChange("login"); old.(bool) && !new.(bool) {
+		return fmt.Errorf("refusing to disable login of role %s, the provider authenticates as it. Set allow_self_lockout in the provider to override", d.Id())
+	}
+	if old, new := d.GetChange("super_user"); old.(bool) && !new.(bool) {
+		return fmt.Errorf("refusing to revoke superuser status from role %s, the provider authenticates as it. Set allow_self_lockout in the provider to override", d.Id())
+	}
+	if d.HasChange("name") {
+		return fmt.Errorf("refusing to replace role %s, the provider authenticates as it. Set allow_self_lockout in the provider to override", d.Id())
+	}
+	return nil
+}
+
+// requireSuperuserOptIn refuses to create superuser roles, or to promote existing
+// roles, unless allow_superuser is set.
+func requireSuperuserOptIn(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	providerConfig, ok := meta.(*ProviderConfig)
+	if !ok || providerConfig.AllowSuperuser {
+		return nil
+	}
+	if old, new := d.GetChange("super_user"); new.(bool) && (d.Id() == "" || !old.(bool)) {
+		return fmt.Errorf("refusing to make role %s a superuser. Set allow_superuser in the provider to opt in", d.Get("name"))
+	}
+	return nil
+}
+
+// validateRolePasswordSource requires one of password, password_wo, hashed_password,
+// generate_password and external_password.
+func validateRolePasswordSource(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || d.Get("generate_password").(bool) || d.Get("external_password").(bool) {
+		return nil
+	}
+	if config.GetAttr("password").IsNull() && config.GetAttr("password_wo").IsNull() && config.GetAttr("hashed_password").IsNull() {
+		return fmt.Errorf("one of password, password_wo, hashed_password, generate_password or external_password must be set")
+	}
+	return nil
+}
+
+// rawConfigReader is implemented by schema.ResourceData and schema.ResourceDiff.
+type rawConfigReader interface {
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// writeOnlyPassword returns password_wo from the configuration, the only place it is available.
+func writeOnlyPassword(d rawConfigReader) string {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath("password_wo"))
+	if diags.HasError() || value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+	return value.AsString()
+}
+
+const (
+	// roleImportReset leaves the password out of the state, the next apply sets the configured password
+	roleImportReset = "reset"
+	// roleImportHash reads the stored hash into hashed_password
+	roleImportHash = "hash"
+)
+
+// resourceRoleImport accepts IDs of the form name or name|strategy, where strategy
+// decides how the password, which cannot be read back, is handled. Roles imported
+// by the name attribute of their identity are imported with the reset strategy.
+func resourceRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	identity, err := identityValues(d, "name")
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		d.SetId(identity["name"])
+	}
+
+	name, strategy, _ := strings.Cut(d.Id(), "|")
+	if name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected name or name|strategy", d.Id())
+	}
+	d.SetId(name)
+
+	switch strategy {
+	case "", roleImportReset:
+	case roleImportHash:
+		providerConfig := meta.(*ProviderConfig)
+		session, err := providerConfig.createSession(d)
+		if err != nil {
+			return nil, err
+		}
+		defer session.Close()
+
+		_, _, _, saltedHash, err := readRole(ctx, session, name, providerConfig.SystemKeyspaceName)
+		if err != nil {
+			return nil, err
+		}
+		d.Set("hashed_password", saltedHash)
+	default:
+		return nil, fmt.Errorf("unknown password import strategy %s, expected %s or %s", strategy, roleImportReset, roleImportHash)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+var bcryptHashRegex = regexp.MustCompile(`^\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}$`)
+
+// rolePasswordClause returns the WITH clause setting the password of a role,
+// from the hash when one is configured. Role options only accept literals, not
+// bind markers, so the password is escaped instead of bound. Statements carrying
+// it must go through redactStatement before being logged.
+func rolePasswordClause(password string, hashedPassword string) string {
+	if hashedPassword != "" {
+		return fmt.Sprintf("HASHED PASSWORD = %s", quoteLiteral(hashedPassword))
+	}
+	return fmt.Sprintf("PASSWORD = %s", quoteLiteral(password))
+}
+
+// readRole reads the role from the roles table of systemKeyspace, the error is
+// gocql.ErrNotFound when the role does not exist.
+func readRole(ctx context.Context, session *gocql.Session, name string, systemKeyspace string) (string, bool, bool, string, error) {
 	tableName := fmt.Sprintf("%s.roles", systemKeyspace)
 	query := fmt.Sprintf("SELECT role, can_login, is_superuser, salted_hash FROM %s WHERE role = ?", tableName)
-	iter := session.Query(query, name).Iter()
-	defer iter.Close()
 
 	var (
 		role        string
@@ -65,10 +264,104 @@ func readRole(session *gocql.Session, name string, systemKeyspace string) (strin
 		isSuperUser bool
 		saltedHash  string
 	)
-	if iter.Scan(&role, &canLogin, &isSuperUser, &saltedHash) {
-		return role, canLogin, isSuperUser, saltedHash, nil
+	if err := session.Query(query, name).WithContext(ctx).Scan(&role, &canLogin, &isSuperUser, &saltedHash); err != nil {
+		return "", false, false, "", err
 	}
-	return "", false, false, "", fmt.Errorf("cannot read role with name %s", name)
+	return role, canLogin, isSuperUser, saltedHash, nil
+}
+
+// changeDetector is implemented by *schema.ResourceData.
+type changeDetector interface {
+	HasChange(key string) bool
+}
+
+// roleSettings are the settings of a role rendered as WITH clauses.
+type roleSettings struct {
+	Password       string
+	HashedPassword string
+	Login          bool
+	SuperUser      bool
+	Options        map[string]interface{}
+	Datacenters    []string
+}
+
+// roleClauses returns the WITH clauses of CREATE ROLE, or for ALTER ROLE only those
+// of changed attributes, so that e.g. toggling login does not resend the password.
+func roleClauses(d changeDetector, createRole bool, settings roleSettings) []string {
+	var clauses []string
+	if createRole || d.HasChange("password") || d.HasChange("hashed_password") || d.HasChange("password_version") {
+		if settings.Password != "" || settings.HashedPassword != "" {
+			clauses = append(clauses, rolePasswordClause(settings.Password, settings.HashedPassword))
+		}
+	}
+	if createRole || d.HasChange("login") {
+		clauses = append(clauses, fmt.Sprintf("LOGIN = %v", settings.Login))
+	}
+	if createRole || d.HasChange("super_user") {
+		clauses = append(clauses, fmt.Sprintf("SUPERUSER = %v", settings.SuperUser))
+	}
+	if (createRole && len(settings.Options) > 0) || d.HasChange("options") {
+		clauses = append(clauses, roleOptionsClause(settings.Options))
+	}
+	if (createRole && len(settings.Datacenters) > 0) || d.HasChange("access_to_datacenters") {
+		clauses = append(clauses, roleDatacentersClause(settings.Datacenters))
+	}
+	return clauses
+}
+
+// roleDatacentersClause restricts the datacenters a role may access, all of them
+// when datacenters is empty.
+func roleDatacentersClause(datacenters []string) string {
+	if len(datacenters) == 0 {
+		return "ACCESS TO ALL DATACENTERS"
+	}
+	sort.Strings(datacenters)
+	quoted := make([]string, 0, len(datacenters))
+	for _, datacenter := range datacenters {
+		quoted = append(quoted, quoteLiteral(datacenter))
+	}
+	return fmt.Sprintf("ACCESS TO DATACENTERS {%s}", strings.Join(quoted, ", "))
+}
+
+// readRoleDatacenters returns the datacenters the role is restricted to, none
+// when it may access all of them. Requires Cassandra 4.0 or later.
+func readRoleDatacenters(ctx context.Context, session *gocql.Session, name string, systemKeyspace string) ([]string, error) {
+	var datacenters []string
+	err := session.Query(fmt.Sprintf(`SELECT dcs FROM %s.network_permissions WHERE role = ?`, systemKeyspace), "roles/"+name).WithContext(ctx).Scan(&datacenters)
+	if err == gocql.ErrNotFound {
+		return nil, nil
+	}
+	return datacenters, err
+}
+
+func roleOptionsClause(options map[string]interface{}) string {
+	entries := make([]string, 0, len(options))
+	for _, key := range sortedKeys(options) {
+		entries = append(entries, fmt.Sprintf("%s: %s", quoteLiteral(key), quoteLiteral(options[key].(string))))
+	}
+	return fmt.Sprintf("OPTIONS = {%s}", strings.Join(entries, ", "))
+}
+
+// readRoleListing returns the row of LIST ROLES describing the role, which carries
+// the columns the roles table lacks, e.g. the custom options. The columns vary
+// between versions and flavors.
+func readRoleListing(ctx context.Context, session *gocql.Session, name string) (map[string]interface{}, error) {
+	iter := session.Query(fmt.Sprintf(`LIST ROLES OF %s NORECURSIVE`, quoteLiteral(name))).WithContext(ctx).Iter()
+	row := make(map[string]interface{})
+	var listing map[string]interface{}
+	for iter.MapScan(row) {
+		if row["role"] == name {
+			listing = row
+		}
+		row = make(map[string]interface{})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	if listing == nil {
+		return nil, fmt.Errorf("cannot list role with name %s", name)
+	}
+	return listing, nil
 }
 
 func resourceRoleCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, createRole bool) diag.Diagnostics {
@@ -76,36 +369,61 @@ func resourceRoleCreateOrUpdate(ctx context.Context, d *schema.ResourceData, met
 	superUser := d.Get("super_user").(bool)
 	login := d.Get("login").(bool)
 	password := d.Get("password").(string)
+	hashedPassword := d.Get("hashed_password").(string)
 	var diags diag.Diagnostics
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	start := time.Now()
-	session, err := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
-	if err != nil {
-		return diag.FromErr(err)
+	if createRole && d.Get("generate_password").(bool) {
+		generated, err := providerConfig.PasswordPolicy.generate(d.Get("generated_password_length").(int), d.Get("generated_password_special").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		password = generated
 	}
-	defer session.Close()
-
-	action := "CREATE"
-	if !createRole {
-		action = "ALTER"
+	if passwordWO := writeOnlyPassword(d); passwordWO != "" {
+		password = passwordWO
 	}
-	query := fmt.Sprintf(`%s ROLE '%s' WITH PASSWORD = '%s' AND LOGIN = %v AND SUPERUSER = %v`,
-		action, name, password, login, superUser)
-	log.Printf("Executing query: %s", query)
-	if err := session.Query(query).Exec(); err != nil {
-		return diag.FromErr(err)
+	if d.Get("external_password").(bool) {
+		// the password left in the state by a previous source is stale once managed externally
+		password = ""
+	}
+
+	clauses := roleClauses(d, createRole, roleSettings{
+		Password:       password,
+		HashedPassword: hashedPassword,
+		Login:          login,
+		SuperUser:      superUser,
+		Options:        d.Get("options").(map[string]interface{}),
+		Datacenters:    setToArray(d.Get("access_to_datacenters")),
+	})
+	if len(clauses) > 0 {
+		session, err := providerConfig.createSession(d)
+		if err != nil {
+			return errorDiagnostics(err, "", nil)
+		}
+		defer session.Close()
+
+		action := "CREATE ROLE"
+		if createRole && providerConfig.AdoptExisting {
+			action = "CREATE ROLE IF NOT EXISTS"
+		} else if !createRole {
+			action = "ALTER ROLE"
+		}
+		query := fmt.Sprintf(`%s %s WITH %s`, action, quoteLiteral(name), strings.Join(clauses, " AND "))
+		log.Printf("Executing query: %s", redactStatement(query))
+		if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+			return errorDiagnostics(err, query, nil)
+		}
 	}
 
 	d.SetId(name)
 	d.Set("name", name)
 	d.Set("super_user", superUser)
 	d.Set("login", login)
-	d.Set("password", password)
+	if writeOnlyPassword(d) == "" {
+		d.Set("password", password)
+	}
+	d.Set("hashed_password", hashedPassword)
 
 	diags = append(diags, resourceRoleRead(ctx, d, meta)...)
 	return diags
@@ -120,47 +438,77 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	var diags diag.Diagnostics
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	start := time.Now()
-	session, err := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	session, err := providerConfig.createSession(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, "", nil)
 	}
 	defer session.Close()
 
-	_role, login, superUser, _, err := readRole(session, name, providerConfig.SystemKeyspaceName)
-	if err != nil {
-		return diag.FromErr(err)
+	_role, login, superUser, saltedHash, err := readRole(ctx, session, name, providerConfig.SystemKeyspaceName)
+	if err == gocql.ErrNotFound {
+		log.Printf("[WARN] Role '%s' no longer exists, removing it from the state", name)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", nil)
 	}
 
+	if err := setIdentity(d, map[string]string{"name": _role}); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set("name", _role)
 	d.Set("super_user", superUser)
 	d.Set("login", login)
+	if d.Get("hashed_password").(string) != "" {
+		d.Set("hashed_password", saltedHash)
+	} else if password := d.Get("password").(string); password != "" && !d.Get("generate_password").(bool) && !d.Get("external_password").(bool) {
+		// a password changed outside of Terraform no longer matches the stored hash,
+		// clearing it from the state makes the next apply set the configured one again
+		match, ok := passwordMatchesHash(providerConfig.PwEncryptionAlgorithm, password, saltedHash)
+		if saltedHash == "" || (ok && !match) {
+			log.Printf("[WARN] Password of role '%s' was changed outside of Terraform", name)
+			d.Set("password", "")
+		} else if !ok {
+			log.Printf("[WARN] Unable to verify the password of role '%s', the stored hash is not %s", name, providerConfig.PwEncryptionAlgorithm)
+		}
+	}
+	// options are only listed by LIST ROLES, skip the extra query unless they are managed
+	if len(d.Get("options").(map[string]interface{})) > 0 {
+		listing, err := readRoleListing(ctx, session, name)
+		if err != nil {
+			return errorDiagnostics(err, "", cty.GetAttrPath("options"))
+		}
+		options, _ := listing["options"].(map[string]string)
+		d.Set("options", options)
+	}
+	if supported, _ := releaseVersionAtLeast(providerConfig.ReleaseVersion, 4, 0); supported && providerConfig.Mode != modeScylla {
+		datacenters, err := readRoleDatacenters(ctx, session, name, providerConfig.SystemKeyspaceName)
+		if err != nil {
+			return errorDiagnostics(err, "", cty.GetAttrPath("access_to_datacenters"))
+		}
+		d.Set("access_to_datacenters", datacenters)
+	}
 	return diags
 }
 
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	name := d.Get("name").(string)
+	name := d.Id()
 	var diags diag.Diagnostics
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	start := time.Now()
-	session, err := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	if name == providerConfig.Username && !providerConfig.AllowSelfLockout {
+		return diag.Errorf("refusing to drop role %s, the provider authenticates as it. Set allow_self_lockout in the provider to override", name)
+	}
+	session, err := providerConfig.createSession(d)
 	if err != nil {
-		return diag.FromErr(err)
+		return errorDiagnostics(err, "", nil)
 	}
 	defer session.Close()
 
-	query := fmt.Sprintf(`DROP ROLE '%s'`, name)
-	if err := session.Query(query).Exec(); err != nil {
-		return diag.FromErr(err)
+	query := fmt.Sprintf(`DROP ROLE %s`, quoteLiteral(name))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
 	}
 	return diags
 }
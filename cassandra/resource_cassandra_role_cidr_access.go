@@ -0,0 +1,130 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraRoleCIDRAccess() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Restrict the CIDR groups a role may connect from, Cassandra 5.0 and later with the CIDR authorizer. Destroying it allows access from all CIDRs again",
+		CreateContext: resourceRoleCIDRAccessCreateOrUpdate,
+		ReadContext:   resourceRoleCIDRAccessRead,
+		UpdateContext: resourceRoleCIDRAccessCreateOrUpdate,
+		DeleteContext: resourceRoleCIDRAccessDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the role",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"cidr_groups": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+				MinItems:    1,
+				Description: "Names of the CIDR groups the role may connect from",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+func generateRoleCIDRAccessQueryString(role string, cidrGroups []string) string {
+	if len(cidrGroups) == 0 {
+		return fmt.Sprintf(`ALTER ROLE %s WITH ACCESS FROM ALL CIDRS`, quoteLiteral(role))
+	}
+
+	sort.Strings(cidrGroups)
+	groups := make([]string, 0, len(cidrGroups))
+	for _, group := range cidrGroups {
+		groups = append(groups, quoteLiteral(group))
+	}
+	return fmt.Sprintf(`ALTER ROLE %s WITH ACCESS FROM CIDRS {%s}`, quoteLiteral(role), strings.Join(groups, ", "))
+}
+
+func resourceRoleCIDRAccessCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Get("role").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	query := generateRoleCIDRAccessQueryString(role, setToArray(d.Get("cidr_groups")))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(role)
+	diags = append(diags, resourceRoleCIDRAccessRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceRoleCIDRAccessRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	// roles without a row, or with an empty set, may connect from all CIDRs
+	var cidrGroups []string
+	query := fmt.Sprintf(`SELECT cidr_groups FROM %s.cidr_permissions WHERE role = ?`, providerConfig.SystemKeyspaceName)
+	err = session.Query(query, role).WithContext(ctx).Scan(&cidrGroups)
+	if err != nil && err != gocql.ErrNotFound {
+		return errorDiagnostics(err, query, nil)
+	}
+	if len(cidrGroups) == 0 {
+		log.Printf("[WARN] Role '%s' no longer has restricted CIDR access, removing it from the state", role)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("role", role)
+	d.Set("cidr_groups", cidrGroups)
+	return diags
+}
+
+func resourceRoleCIDRAccessDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Id()
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	query := generateRoleCIDRAccessQueryString(role, nil)
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		if strings.Contains(err.Error(), "doesn't exist") {
+			return diags
+		}
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
@@ -0,0 +1,17 @@
+package cassandra
+
+import "testing"
+
+func TestGenerateRoleCIDRAccessQueryString(t *testing.T) {
+	query := generateRoleCIDRAccessQueryString("app", []string{"office", "datacenter"})
+	expected := `ALTER ROLE 'app' WITH ACCESS FROM CIDRS {'datacenter', 'office'}`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateRoleCIDRAccessQueryString("app", nil)
+	expected = `ALTER ROLE 'app' WITH ACCESS FROM ALL CIDRS`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
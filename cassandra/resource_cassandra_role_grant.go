@@ -0,0 +1,165 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraRoleGrant() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Grant a role to another role, which inherits its permissions",
+		CreateContext: resourceRoleGrantCreate,
+		ReadContext:   resourceRoleGrantRead,
+		UpdateContext: resourceRoleGrantUpdate,
+		DeleteContext: resourceRoleGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRoleGrantImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the granted role",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"grantee": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the role the role is granted to",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+func roleGrantID(role string, grantee string) string {
+	return fmt.Sprintf("%s|%s", role, grantee)
+}
+
+// resourceRoleGrantImport accepts IDs of the form role|grantee.
+func resourceRoleGrantImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	role, grantee, ok := strings.Cut(d.Id(), "|")
+	if !ok || role == "" || grantee == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected role|grantee", d.Id())
+	}
+
+	d.Set("role", role)
+	d.Set("grantee", grantee)
+	return []*schema.ResourceData{d}, nil
+}
+
+// readGrantedRoles returns the roles granted directly to grantee. ok is false when
+// the grantee does not exist.
+func readGrantedRoles(session *gocql.Session, grantee string) (map[string]bool, bool, error) {
+	iter := session.Query(fmt.Sprintf(`LIST ROLES OF %s NORECURSIVE`, quoteLiteral(grantee))).Iter()
+	roles := make(map[string]bool)
+	// the columns besides role (super, login, options...) vary between versions
+	row := make(map[string]interface{})
+	for iter.MapScan(row) {
+		if role, ok := row["role"].(string); ok {
+			roles[role] = true
+		}
+		row = make(map[string]interface{})
+	}
+	if err := iter.Close(); err != nil {
+		if strings.Contains(err.Error(), "doesn't exist") {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return roles, true, nil
+}
+
+func resourceRoleGrantCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Get("role").(string)
+	grantee := d.Get("grantee").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	query := fmt.Sprintf(`GRANT %s TO %s`, quoteLiteral(role), quoteLiteral(grantee))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(roleGrantID(role, grantee))
+	diags = append(diags, resourceRoleGrantRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceRoleGrantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Get("role").(string)
+	grantee := d.Get("grantee").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	roles, ok, err := readGrantedRoles(session, grantee)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	if !ok || !roles[role] {
+		log.Printf("[WARN] Role '%s' is no longer granted to '%s', removing it from the state", role, grantee)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("role", role)
+	d.Set("grantee", grantee)
+	return diags
+}
+
+// resourceRoleGrantUpdate only applies consistency changes, every other attribute forces a new grant.
+func resourceRoleGrantUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceRoleGrantRead(ctx, d, meta)
+}
+
+func resourceRoleGrantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	role := d.Get("role").(string)
+	grantee := d.Get("grantee").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(d)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	defer session.Close()
+
+	roles, ok, err := readGrantedRoles(session, grantee)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	if !ok || !roles[role] {
+		log.Printf("[WARN] Role '%s' is not granted to '%s' anymore, nothing to revoke", role, grantee)
+		return diags
+	}
+
+	query := fmt.Sprintf(`REVOKE %s FROM %s`, quoteLiteral(role), quoteLiteral(grantee))
+	log.Printf("Executing query: %s", query)
+	if err := session.Query(query).WithContext(ctx).Exec(); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
@@ -0,0 +1,47 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraRoleGrant_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleGrantConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_role_grant.reader", "role", "reader"),
+					resource.TestCheckResourceAttr("cassandra_role_grant.reader", "grantee", "app"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_role_grant.reader",
+				ImportStateId:     "reader|app",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraRoleGrantConfig = `
+resource "cassandra_role" "reader" {
+  name     = "reader"
+  login    = false
+  password = "reader-password-with-at-least-forty-characters"
+}
+
+resource "cassandra_role" "app" {
+  name     = "app"
+  password = "app-password-with-at-least-forty-characters!"
+}
+
+resource "cassandra_role_grant" "reader" {
+  role    = cassandra_role.reader.name
+  grantee = cassandra_role.app.name
+}
+`
@@ -1,10 +1,13 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -29,6 +32,230 @@ func TestAccCassandraRole_basic(t *testing.T) {
 	})
 }
 
+func TestAccCassandraRole_hashedPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleConfigHashedPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraRoleExists("cassandra_role.hashed"),
+					resource.TestCheckResourceAttr("cassandra_role.hashed", "hashed_password", testAccBcryptHash),
+				),
+			},
+			{
+				ResourceName:            "cassandra_role.hashed",
+				ImportStateId:           "hashed|hash",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"generate_password", "generated_password_length", "generated_password_special"},
+			},
+		},
+	})
+}
+
+func TestAccCassandraRole_generatePassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleConfigGeneratePassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraRoleExists("cassandra_role.generated"),
+					resource.TestMatchResourceAttr("cassandra_role.generated", "password", regexp.MustCompile(`^[A-Za-z0-9]{48}$`)),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraRoleConfigGeneratePassword = `
+resource "cassandra_role" "generated" {
+  name                       = "generated"
+  generate_password          = true
+  generated_password_length  = 48
+  generated_password_special = false
+}
+`
+
+func TestAccCassandraRole_externalPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleConfigExternalPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraRoleExists("cassandra_role.external"),
+					resource.TestCheckResourceAttr("cassandra_role.external", "password", ""),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraRoleConfigExternalPassword = `
+resource "cassandra_role" "external" {
+  name              = "external"
+  external_password = true
+}
+`
+
+func TestAccCassandraRole_writeOnlyPassword(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleConfigWriteOnlyPassword("asdf1234", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraRoleExists("cassandra_role.write_only"),
+					resource.TestCheckNoResourceAttr("cassandra_role.write_only", "password_wo"),
+					resource.TestCheckResourceAttr("cassandra_role.write_only", "password_version", "1"),
+				),
+			},
+			{
+				Config: testAccCassandraRoleConfigWriteOnlyPassword("qwer5678", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("cassandra_role.write_only", "password_wo"),
+					resource.TestCheckResourceAttr("cassandra_role.write_only", "password_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCassandraRoleConfigWriteOnlyPassword(password string, version int) string {
+	return fmt.Sprintf(`
+resource "cassandra_role" "write_only" {
+  name             = "write_only"
+  password_wo      = "%s"
+  password_version = %d
+}
+`, password, version)
+}
+
+func TestWriteOnlyPassword(t *testing.T) {
+	role := resourceCassandraRole()
+	d := role.Data(&terraform.InstanceState{ID: "app", RawConfig: cty.ObjectVal(map[string]cty.Value{"password_wo": cty.StringVal("asdf1234")})})
+	if password := writeOnlyPassword(d); password != "asdf1234" {
+		t.Errorf("expected asdf1234, got %s", password)
+	}
+	d = role.Data(&terraform.InstanceState{ID: "app", RawConfig: cty.ObjectVal(map[string]cty.Value{"password_wo": cty.NullVal(cty.String)})})
+	if password := writeOnlyPassword(d); password != "" {
+		t.Errorf("expected no password, got %s", password)
+	}
+	if password := writeOnlyPassword(role.Data(nil)); password != "" {
+		t.Errorf("expected no password, got %s", password)
+	}
+}
+
+// changedAttributes implements changeDetector for the listed attributes.
+type changedAttributes []string
+
+func (c changedAttributes) HasChange(key string) bool {
+	for _, attribute := range c {
+		if attribute == key {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRoleClauses(t *testing.T) {
+	for _, test := range []struct {
+		changed    changedAttributes
+		createRole bool
+		expected   string
+	}{
+		{nil, true, "PASSWORD = 'asdf1234' AND LOGIN = true AND SUPERUSER = false AND OPTIONS = {'dse_tier': 'gold', 'team': 'o''brien'} AND ACCESS TO DATACENTERS {'dc1', 'dc2'}"},
+		{changedAttributes{"login"}, false, "LOGIN = true"},
+		{changedAttributes{"super_user", "login"}, false, "LOGIN = true AND SUPERUSER = false"},
+		{changedAttributes{"password_version"}, false, "PASSWORD = 'asdf1234'"},
+		{changedAttributes{"consistency"}, false, ""},
+		{changedAttributes{"options"}, false, "OPTIONS = {'dse_tier': 'gold', 'team': 'o''brien'}"},
+		{changedAttributes{"access_to_datacenters"}, false, "ACCESS TO DATACENTERS {'dc1', 'dc2'}"},
+	} {
+		clauses := strings.Join(roleClauses(test.changed, test.createRole, roleSettings{
+			Password:    "asdf1234",
+			Login:       true,
+			Options:     map[string]interface{}{"team": "o'brien", "dse_tier": "gold"},
+			Datacenters: []string{"dc2", "dc1"},
+		}), " AND ")
+		if clauses != test.expected {
+			t.Errorf("expected %q for %v, got %q", test.expected, test.changed, clauses)
+		}
+	}
+}
+
+func TestAccCassandraRole_accessToDatacenters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCassandraRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraRoleConfigAccessToDatacenters,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCassandraRoleExists("cassandra_role.analytics"),
+					resource.TestCheckResourceAttr("cassandra_role.analytics", "access_to_datacenters.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraRoleConfigAccessToDatacenters = `
+resource "cassandra_role" "analytics" {
+  name                  = "analytics"
+  password              = "asdf1234"
+  access_to_datacenters = ["datacenter1"]
+}
+`
+
+func TestRoleDatacentersClause(t *testing.T) {
+	if clause := roleDatacentersClause(nil); clause != "ACCESS TO ALL DATACENTERS" {
+		t.Errorf("unexpected clause %s", clause)
+	}
+	if clause := roleDatacentersClause([]string{"analytics"}); clause != "ACCESS TO DATACENTERS {'analytics'}" {
+		t.Errorf("unexpected clause %s", clause)
+	}
+}
+
+func TestRolePasswordClause(t *testing.T) {
+	if clause := rolePasswordClause("it's", ""); clause != "PASSWORD = 'it''s'" {
+		t.Errorf("expected the password to be escaped, got %s", clause)
+	}
+	if clause := rolePasswordClause("asdf1234", ""); clause != "PASSWORD = 'asdf1234'" {
+		t.Errorf("unexpected clause %s", clause)
+	}
+	if clause := rolePasswordClause("", testAccBcryptHash); clause != "HASHED PASSWORD = '"+testAccBcryptHash+"'" {
+		t.Errorf("unexpected clause %s", clause)
+	}
+	if !bcryptHashRegex.MatchString(testAccBcryptHash) {
+		t.Errorf("expected %s to be accepted as a bcrypt hash", testAccBcryptHash)
+	}
+	if bcryptHashRegex.MatchString("asdf1234") {
+		t.Error("expected a plaintext password to be rejected as a bcrypt hash")
+	}
+}
+
+// testAccBcryptHash is the bcrypt hash of asdf1234.
+const testAccBcryptHash = "$2a$10$fmZ0ga2qDFk86uGrUPr.6OAJXODRhJAoYgY5fZMMNHb7XsEU0AVKS"
+
+const testAccCassandraRoleConfigHashedPassword = `
+resource "cassandra_role" "hashed" {
+  name            = "hashed"
+  hashed_password = "$2a$10$fmZ0ga2qDFk86uGrUPr.6OAJXODRhJAoYgY5fZMMNHb7XsEU0AVKS"
+}
+`
+
 func TestAccCassandraRole_invalid(t *testing.T) {
 	name := "invalid\\\"name"
 
@@ -70,7 +297,7 @@ func testAccCassandraRoleDestroy(s *terraform.State) error {
 		}
 
 		name := rs.Primary.Attributes["name"]
-		_, _, _, _, err := readRole(session, name, pc.Mode)
+		_, _, _, _, err := readRole(context.Background(), session, name, pc.SystemKeyspaceName)
 		if err != nil {
 			return nil
 		}
@@ -96,10 +323,82 @@ func testAccCassandraRoleExists(resourceKey string) resource.TestCheckFunc {
 		}
 		defer session.Close()
 
-		_, _, _, _, err := readRole(session, rs.Primary.ID, pc.Mode)
+		_, _, _, _, err := readRole(context.Background(), session, rs.Primary.ID, pc.SystemKeyspaceName)
 		if err != nil {
 			return err
 		}
 		return nil
 	}
 }
+
+func TestProtectOwnRole(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "admin",
+		Attributes: map[string]string{
+			"name":       "admin",
+			"password":   "asdf1234",
+			"login":      "true",
+			"super_user": "true",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "admin",
+		"password":   "asdf1234",
+		"super_user": false,
+	})
+
+	meta := &ProviderConfig{Username: "admin", PasswordPolicy: passwordPolicy{MinLength: 8}}
+	if _, err := resourceCassandraRole().Diff(context.Background(), state, config, meta); err == nil || !strings.Contains(err.Error(), "refusing to revoke superuser status") {
+		t.Errorf("expected demoting the provider's own role to be refused, got %v", err)
+	}
+
+	meta.AllowSelfLockout = true
+	if _, err := resourceCassandraRole().Diff(context.Background(), state, config, meta); err != nil {
+		t.Errorf("unexpected error with allow_self_lockout: %s", err)
+	}
+
+	meta = &ProviderConfig{Username: "terraform", PasswordPolicy: passwordPolicy{MinLength: 8}}
+	if _, err := resourceCassandraRole().Diff(context.Background(), state, config, meta); err != nil {
+		t.Errorf("unexpected error for another role: %s", err)
+	}
+}
+
+func TestRequireSuperuserOptIn(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "admin",
+		"password":   "asdf1234",
+		"super_user": true,
+	})
+
+	meta := &ProviderConfig{PasswordPolicy: passwordPolicy{MinLength: 8}}
+	if _, err := resourceCassandraRole().Diff(context.Background(), nil, config, meta); err == nil || !strings.Contains(err.Error(), "allow_superuser") {
+		t.Errorf("expected creating a superuser to be refused, got %v", err)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "admin",
+		Attributes: map[string]string{
+			"name":       "admin",
+			"password":   "asdf1234",
+			"login":      "true",
+			"super_user": "false",
+			// ForceNew attributes, a replacement would create the role again
+			"generate_password":          "false",
+			"generated_password_length":  "32",
+			"generated_password_special": "true",
+		},
+	}
+	if _, err := resourceCassandraRole().Diff(context.Background(), state, config, meta); err == nil || !strings.Contains(err.Error(), "allow_superuser") {
+		t.Errorf("expected promoting a role to be refused, got %v", err)
+	}
+
+	state.Attributes["super_user"] = "true"
+	if _, err := resourceCassandraRole().Diff(context.Background(), state, config, meta); err != nil {
+		t.Errorf("unexpected error for an existing superuser: %s", err)
+	}
+
+	meta.AllowSuperuser = true
+	if _, err := resourceCassandraRole().Diff(context.Background(), nil, config, meta); err != nil {
+		t.Errorf("unexpected error with allow_superuser: %s", err)
+	}
+}
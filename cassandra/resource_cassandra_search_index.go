@@ -0,0 +1,252 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// searchIndexClass is the custom index class DSE registers search indexes with.
+const searchIndexClass = "com.datastax.bdp.search.solr.Cql3SolrSecondaryIndex"
+
+func resourceCassandraSearchIndex() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage DataStax Enterprise search indexes. Config changes are applied with ALTER SEARCH INDEX CONFIG followed by RELOAD SEARCH INDEX",
+		CreateContext: resourceSearchIndexCreate,
+		ReadContext:   resourceSearchIndexRead,
+		UpdateContext: resourceSearchIndexUpdate,
+		DeleteContext: resourceSearchIndexDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSearchIndexImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace of the indexed table, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"table": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the indexed table, a table has at most one search index",
+			},
+			"columns": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Columns to index, optionally followed by their options, e.g. \"name { docValues : true }\". All columns are indexed when not set",
+			},
+			"profiles": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Profiles applied to the generated schema, e.g. spaceSavingAll",
+			},
+			"config": {
+				Type:             schema.TypeMap,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Optional:         true,
+				Description:      "Config of the search index, e.g. realtime or autoCommitTime. Changes are applied in place",
+				ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile(`^[A-Za-z][\w.]*$`), "must be a search index config element, e.g. autoCommitTime"),
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// resourceSearchIndexImport accepts IDs of the form keyspace.table.
+func resourceSearchIndexImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keyspaceName, table, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || table == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.table", d.Id())
+	}
+
+	d.SetId(table)
+	d.Set("keyspace", keyspaceName)
+	d.Set("table", table)
+	return []*schema.ResourceData{d}, nil
+}
+
+// searchIndexValue renders a config value, numbers and booleans are left unquoted.
+func searchIndexValue(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	if value == "true" || value == "false" {
+		return value
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func generateCreateSearchIndexQueryString(keyspace string, table string, ifNotExists bool, columns []string, profiles []string, config map[string]interface{}) string {
+	action := "CREATE SEARCH INDEX"
+	if ifNotExists {
+		action = "CREATE SEARCH INDEX IF NOT EXISTS"
+	}
+	query := fmt.Sprintf(`%s ON %q.%q`, action, unquoteIdentifier(keyspace), table)
+
+	var clauses []string
+	if len(columns) > 0 {
+		clauses = append(clauses, "COLUMNS "+strings.Join(columns, ", "))
+	}
+	if len(profiles) > 0 {
+		clauses = append(clauses, "PROFILES "+strings.Join(profiles, ", "))
+	}
+	if len(config) > 0 {
+		entries := make([]string, 0, len(config))
+		for _, key := range sortedKeys(config) {
+			entries = append(entries, fmt.Sprintf("%s : %s", key, searchIndexValue(config[key].(string))))
+		}
+		clauses = append(clauses, fmt.Sprintf("CONFIG { %s }", strings.Join(entries, ", ")))
+	}
+	if len(clauses) > 0 {
+		query += " WITH " + strings.Join(clauses, " AND ")
+	}
+	return query
+}
+
+// alterSearchIndexConfigQueries returns the statements changing the config of a
+// search index from old to new, the index must be reloaded afterwards.
+func alterSearchIndexConfigQueries(keyspace string, table string, old map[string]interface{}, new map[string]interface{}) []string {
+	var queries []string
+	for _, key := range sortedKeys(old) {
+		if _, ok := new[key]; !ok {
+			queries = append(queries, fmt.Sprintf(`ALTER SEARCH INDEX CONFIG ON %q.%q DROP %s`, keyspace, table, key))
+		}
+	}
+	for _, key := range sortedKeys(new) {
+		if oldValue, ok := old[key]; !ok || oldValue != new[key] {
+			queries = append(queries, fmt.Sprintf(`ALTER SEARCH INDEX CONFIG ON %q.%q SET %s = %s`, keyspace, table, key, searchIndexValue(new[key].(string))))
+		}
+	}
+	return queries
+}
+
+func resourceSearchIndexCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	query := generateCreateSearchIndexQueryString(keyspaceName, table, providerConfig.AdoptExisting, listToArray(d.Get("columns")), listToArray(d.Get("profiles")), d.Get("config").(map[string]interface{}))
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Creating search index on '%s' in '%s'", table, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(table)
+	diags = append(diags, resourceSearchIndexRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceSearchIndexRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	table := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	// the search config lives in DSE Search, only the existence of the index is read
+	found := false
+	iter := session.Query(`SELECT options FROM system_schema.indexes WHERE keyspace_name = ? AND table_name = ?`, unquoteIdentifier(keyspaceName), table).WithContext(ctx).Iter()
+	var options map[string]string
+	for iter.Scan(&options) {
+		if options["class_name"] == searchIndexClass {
+			found = true
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+	if !found {
+		log.Printf("[WARN] Search index on '%s' no longer exists in '%s', removing it from the state", table, keyspaceName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("keyspace", keyspaceName)
+	d.Set("table", table)
+	return diags
+}
+
+func resourceSearchIndexUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	table := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	if d.HasChange("config") {
+		old, new := d.GetChange("config")
+		queries := alterSearchIndexConfigQueries(unquoteIdentifier(keyspaceName), table, old.(map[string]interface{}), new.(map[string]interface{}))
+		queries = append(queries, fmt.Sprintf(`RELOAD SEARCH INDEX ON %q.%q`, unquoteIdentifier(keyspaceName), table))
+
+		providerConfig := meta.(*ProviderConfig)
+		session, sessionCreateError := providerConfig.createSession(d)
+		if sessionCreateError != nil {
+			return errorDiagnostics(sessionCreateError, "", nil)
+		}
+		defer session.Close()
+
+		for _, query := range queries {
+			if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+				return errorDiagnostics(err, query, nil)
+			}
+		}
+	}
+
+	diags = append(diags, resourceSearchIndexRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceSearchIndexDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	table := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Deleting search index on '%s' in '%s'", table, keyspaceName)
+	query := fmt.Sprintf(`DROP SEARCH INDEX ON %q.%q`, unquoteIdentifier(keyspaceName), table)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
@@ -0,0 +1,40 @@
+package cassandra
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateCreateSearchIndexQueryString(t *testing.T) {
+	query := generateCreateSearchIndexQueryString("shop", "orders", false, nil, nil, nil)
+	expected := `CREATE SEARCH INDEX ON "shop"."orders"`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+
+	query = generateCreateSearchIndexQueryString("shop", "orders", true, []string{"name", "email { docValues : true }"}, []string{"spaceSavingAll"}, map[string]interface{}{
+		"realtime":         "true",
+		"autoCommitTime":   "1000",
+		"directoryFactory": "encrypted",
+	})
+	expected = `CREATE SEARCH INDEX IF NOT EXISTS ON "shop"."orders" WITH COLUMNS name, email { docValues : true } AND PROFILES spaceSavingAll AND CONFIG { autoCommitTime : 1000, directoryFactory : 'encrypted', realtime : true }`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestAlterSearchIndexConfigQueries(t *testing.T) {
+	queries := alterSearchIndexConfigQueries("shop", "orders", map[string]interface{}{
+		"realtime":       "true",
+		"autoCommitTime": "1000",
+	}, map[string]interface{}{
+		"autoCommitTime": "5000",
+	})
+	expected := []string{
+		`ALTER SEARCH INDEX CONFIG ON "shop"."orders" DROP realtime`,
+		`ALTER SEARCH INDEX CONFIG ON "shop"."orders" SET autoCommitTime = 5000`,
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected %v, got %v", expected, queries)
+	}
+}
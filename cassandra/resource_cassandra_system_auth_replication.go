@@ -0,0 +1,118 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// systemReplicatedKeyspaces are the system keyspaces whose replication operators
+// are expected to tune, e.g. raising system_auth replication when enabling authentication.
+var systemReplicatedKeyspaces = []string{"system_auth", "system_traces", "system_distributed"}
+
+func resourceCassandraSystemAuthReplication() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage the replication of the system_auth, system_traces and system_distributed keyspaces. The keyspaces are never dropped, destroying the resource leaves their replication as is",
+		CreateContext: resourceSystemAuthReplicationCreateOrUpdate,
+		ReadContext:   resourceSystemAuthReplicationRead,
+		UpdateContext: resourceSystemAuthReplicationCreateOrUpdate,
+		DeleteContext: resourceSystemAuthReplicationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"keyspace": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "system_auth",
+				Description:  "System keyspace to manage, one of system_auth, system_traces or system_distributed",
+				ValidateFunc: validation.StringInSlice(systemReplicatedKeyspaces, false),
+			},
+			"replication_strategy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Keyspace replication strategy - must be one of SimpleStrategy or NetworkTopologyStrategy",
+				ValidateFunc: validation.StringInSlice([]string{"SimpleStrategy", "NetworkTopologyStrategy"}, false),
+			},
+			"strategy_options": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "strategy options used with replication strategy, e.g. the replication factor of each datacenter for NetworkTopologyStrategy",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+func resourceSystemAuthReplicationCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspace := d.Get("keyspace").(string)
+	replicationStrategy := d.Get("replication_strategy").(string)
+	strategyOptions := d.Get("strategy_options").(map[string]interface{})
+	var diags diag.Diagnostics
+
+	query, err := generateCreateOrUpdateKeyspaceQueryString(keyspace, false, false, replicationStrategy, strategyOptions, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	err = providerConfig.executeDDL(ctx, session, query)
+	if err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	diags = append(diags, replicationChangeWarning(keyspace))
+
+	d.SetId(keyspace)
+	diags = append(diags, resourceSystemAuthReplicationRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceSystemAuthReplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspace := d.Id()
+	providerConfig := meta.(*ProviderConfig)
+	var diags diag.Diagnostics
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	keyspaceMetadata, err := session.KeyspaceMetadata(keyspace)
+	if err == gocql.ErrKeyspaceDoesNotExist {
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+
+	strategyOptions := make(map[string]string)
+	for key, value := range keyspaceMetadata.StrategyOptions {
+		strategyOptions[key] = fmt.Sprint(value)
+	}
+
+	d.Set("keyspace", keyspace)
+	d.Set("replication_strategy", shortStrategyClass(keyspaceMetadata.StrategyClass))
+	d.Set("strategy_options", strategyOptions)
+	return diags
+}
+
+func resourceSystemAuthReplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Leaving the replication of %s unchanged, system keyspaces are never dropped", d.Id())
+	return nil
+}
@@ -0,0 +1,39 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCassandraSystemAuthReplication_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraSystemAuthReplicationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_system_auth_replication.system_auth", "keyspace", "system_auth"),
+					resource.TestCheckResourceAttr("cassandra_system_auth_replication.system_auth", "replication_strategy", "SimpleStrategy"),
+					resource.TestCheckResourceAttr("cassandra_system_auth_replication.system_auth", "strategy_options.replication_factor", "1"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_system_auth_replication.system_auth",
+				ImportStateId:     "system_auth",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraSystemAuthReplicationConfig = `
+resource "cassandra_system_auth_replication" "system_auth" {
+    replication_strategy = "SimpleStrategy"
+    strategy_options     = {
+      replication_factor = 1
+    }
+}
+`
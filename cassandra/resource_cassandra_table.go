@@ -5,12 +5,19 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/kristoiv/gocqltable"
 )
 
 func resourceCassandraTableSpace() *schema.Resource {
@@ -18,9 +25,36 @@ func resourceCassandraTableSpace() *schema.Resource {
 		Description:   "Create and Delete Tables within Keyspaces",
 		CreateContext: resourceTableCreate,
 		ReadContext:   resourceTableRead,
+		UpdateContext: resourceTableUpdate,
 		DeleteContext: resourceTableDelete,
+		CustomizeDiff: customdiff.All(
+			validateTableKeys,
+			validateBillingMode,
+			validateTableOptionVersions,
+			forceNewOnColumnChange,
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceTableImport,
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"keyspace": identityAttributeSchema(true, "Keyspace of the table"),
+					"name":     identityAttributeSchema(true, "Name of the table"),
+				}
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCassandraTableV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceTableStateUpgradeV0,
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -34,7 +68,7 @@ func resourceCassandraTableSpace() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Keyspace to create table within",
+				Description: "Keyspace to create table within, quote it (e.g. \"MyKeyspace\") for case sensitive names",
 			},
 			"attribute": {
 				Type: schema.TypeSet,
@@ -57,75 +91,929 @@ func resourceCassandraTableSpace() *schema.Resource {
 					buf.WriteString(fmt.Sprintf("%s-", m["name"].(string)))
 					return stringHashcode(buf.String())
 				},
-				Required:    true,
-				ForceNew:    true,
-				Description: "List of Row Keys",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "List of Row Keys",
+				Deprecated:   "use column blocks, which keep their order and take CQL types",
+				ExactlyOneOf: []string{"attribute", "column"},
+			},
+			"column": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the column",
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "CQL type of the column, e.g. text, int, map<text, int> or frozen<address>",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+				Optional:     true,
+				Computed:     true,
+				Description:  "Ordered columns of the table. Removing columns replaces the table unless allow_column_drops is set, any other change replaces it. When migrating from attribute blocks, list the columns in the order recorded in the state to avoid replacing the table",
+				ExactlyOneOf: []string{"attribute", "column"},
 			},
 			"row_keys": {
-				Type:        schema.TypeSet,
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "List of Row Primary Keys",
+				Deprecated:    "use partition_keys, whose order is kept",
+				ConflictsWith: []string{"partition_keys"},
+			},
+			"range_keys": {
+				Type:          schema.TypeSet,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "List of Range Keys",
+				Deprecated:    "use clustering_keys, whose order is kept",
+				ConflictsWith: []string{"clustering_keys"},
+			},
+			"partition_keys": {
+				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Set:         schema.HashString,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "List of Row Primary Keys",
+				Description: "Ordered columns of the partition key, more than one make a composite partition key",
 			},
-			"range_keys": {
-				Type:        schema.TypeSet,
+			"clustering_keys": {
+				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
-				Set:         schema.HashString,
 				Optional:    true,
 				ForceNew:    true,
-				Description: "List of Range Keys",
+				Description: "Ordered clustering columns of the primary key",
+			},
+			"compaction": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Compaction options of the table, e.g. class = TimeWindowCompactionStrategy, compaction_window_unit = DAYS. Changes are applied in place",
+				ValidateDiagFunc: func(i interface{}, path cty.Path) diag.Diagnostics {
+					if _, ok := i.(map[string]interface{})["class"]; !ok {
+						return diag.Diagnostics{
+							{
+								Severity:      diag.Error,
+								Summary:       "Missing compaction class",
+								Detail:        "compaction must set the class of the compaction strategy",
+								AttributePath: path,
+							},
+						}
+					}
+					return nil
+				},
+			},
+			"gc_grace_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Seconds tombstones are kept before being garbage collected. Changes are applied in place",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"default_time_to_live": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Default time to live of the rows in seconds, 0 disables expiration. Changes are applied in place",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"bloom_filter_fp_chance": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Target false positive probability of the SSTable bloom filters, between 0 exclusive and 1. Changes are applied in place",
+				ValidateFunc: validation.FloatBetween(0.000001, 1),
+			},
+			"crc_check_chance": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Probability of verifying the checksum of compressed blocks on read, between 0 and 1. Changes are applied in place",
+				ValidateFunc: validation.FloatBetween(0, 1),
+			},
+			"memtable_flush_period_in_ms": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Milliseconds after which memtables are flushed, 0 flushes only when full. Changes are applied in place",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"paxos_grace_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Seconds Paxos state of lightweight transactions is kept before it is purged, Cassandra 4.1 and later or Scylla. Changes are applied in place",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"allow_auto_snapshot": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether a snapshot is taken when the table is dropped or truncated, Cassandra 5.0 and later. Changes are applied in place",
+			},
+			"memtable": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the memtable configuration of the table, defined under memtable.configurations in cassandra.yaml, e.g. trie. Cassandra 5.0 and later. Changes are applied in place",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"read_repair": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Read repair mode of the table on Cassandra 4.0 and later, BLOCKING or NONE. NONE drops monotonic reads. Changes are applied in place",
+				ValidateFunc: validation.StringInSlice([]string{"BLOCKING", "NONE"}, false),
 			},
+			"cdc": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "Enable change data capture on Cassandra, requires cdc_enabled in cassandra.yaml. Changes are applied in place",
+				ConflictsWith: []string{"scylla_cdc"},
+			},
+			"scylla_cdc": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Description:      "CDC options of the table on Scylla: enabled, preimage, postimage and ttl. Changes are applied in place",
+				ConflictsWith:    []string{"cdc"},
+				ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile(`^(enabled|preimage|postimage|ttl|delta)$`), "must be one of enabled, preimage, postimage, ttl or delta"),
+			},
+			"billing_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Amazon Keyspaces capacity mode of the table, on_demand or provisioned. Changes are applied in place",
+				ValidateFunc: validation.StringInSlice([]string{billingModeOnDemand, billingModeProvisioned}, false),
+			},
+			"read_capacity_units": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Provisioned read capacity units of the table on Amazon Keyspaces, requires billing_mode provisioned",
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"write_capacity_units"},
+			},
+			"write_capacity_units": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Provisioned write capacity units of the table on Amazon Keyspaces, requires billing_mode provisioned",
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"read_capacity_units"},
+			},
+			"point_in_time_recovery": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable point-in-time recovery of the table on Amazon Keyspaces. Changes are applied in place",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Tags of the table on Amazon Keyspaces. Changes are applied in place",
+			},
+			"table_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the table as recorded in system_schema.tables, it changes when the table is recreated",
+			},
+			"allow_column_drops": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Drop columns removed from the configuration with ALTER TABLE DROP instead of replacing the table. The data of the dropped columns is lost",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create the table with IF NOT EXISTS and take over an existing table of the same name. Creation fails when the columns or primary key of the existing table differ from the configuration",
+			},
+			"consistency": resourceConsistencySchema(),
 		},
 	}
 }
 
+// tableID returns the identifier of a table resource, keyspace.table.
+func tableID(keyspaceName string, name string) string {
+	return fmt.Sprintf("%s.%s", keyspaceName, name)
+}
+
+// resourceTableImport addresses a table by "keyspace.table", or by the keyspace
+// and name attributes of its identity.
+func resourceTableImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	identity, err := identityValues(d, "keyspace", "name")
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		d.SetId(tableID(identity["keyspace"], identity["name"]))
+	}
+
+	keyspaceName, name, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.table", d.Id())
+	}
+
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	return []*schema.ResourceData{d}, nil
+}
+
+// tableColumn is a column of a table and its CQL type.
+type tableColumn struct {
+	Name string
+	Type string
+}
+
+// attributeTypes maps the attribute type shorthands to CQL types.
+var attributeTypes = map[string]string{
+	"S": "text",
+	"N": "decimal",
+	"B": "blob",
+}
+
+// tableColumns returns the columns of the table, from the column blocks or the
+// deprecated attribute blocks.
+func tableColumns(d *schema.ResourceData) []tableColumn {
+	var columns []tableColumn
+	if rawColumns := d.Get("column").([]interface{}); len(rawColumns) > 0 {
+		for _, rawColumn := range rawColumns {
+			column := rawColumn.(map[string]interface{})
+			columns = append(columns, tableColumn{
+				Name: column["name"].(string),
+				Type: column["type"].(string),
+			})
+		}
+		return columns
+	}
+	for _, rawAttribute := range d.Get("attribute").(*schema.Set).List() {
+		columns = append(columns, attributeToColumn(rawAttribute.(map[string]interface{})))
+	}
+	return columns
+}
+
+func attributeToColumn(attribute map[string]interface{}) tableColumn {
+	return tableColumn{
+		Name: attribute["name"].(string),
+		Type: attributeTypes[attribute["type"].(string)],
+	}
+}
+
+func flattenTableColumns(columns []tableColumn) []interface{} {
+	flattened := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		flattened = append(flattened, map[string]interface{}{
+			"name": column.Name,
+			"type": column.Type,
+		})
+	}
+	return flattened
+}
+
+// validateTableKeys checks at plan time that the primary key only references
+// columns of the table, the server error for it is hard to relate to the config.
+func validateTableKeys(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, key := range []string{"partition_keys", "clustering_keys", "row_keys", "range_keys"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	// column is computed when the deprecated attribute blocks are used, and the other way around
+	columns := make(map[string]bool)
+	if d.NewValueKnown("column") {
+		for _, rawColumn := range d.Get("column").([]interface{}) {
+			columns[rawColumn.(map[string]interface{})["name"].(string)] = true
+		}
+	}
+	if d.NewValueKnown("attribute") {
+		for _, rawAttribute := range d.Get("attribute").(*schema.Set).List() {
+			columns[rawAttribute.(map[string]interface{})["name"].(string)] = true
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	keyAttributes := map[string][]string{
+		"partition_keys":  listToArray(d.Get("partition_keys")),
+		"clustering_keys": listToArray(d.Get("clustering_keys")),
+		"row_keys":        setToArray(d.Get("row_keys")),
+		"range_keys":      setToArray(d.Get("range_keys")),
+	}
+	for attribute, keys := range keyAttributes {
+		for _, key := range keys {
+			if !columns[key] {
+				return fmt.Errorf("%s references %s, which is not a column of the table", attribute, key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateBillingMode checks that capacity units are set for, and only for, the
+// provisioned capacity mode.
+func validateBillingMode(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("billing_mode") || !d.NewValueKnown("read_capacity_units") {
+		return nil
+	}
+	billingMode := d.Get("billing_mode").(string)
+	hasCapacity := d.Get("read_capacity_units").(int) > 0
+	if billingMode == billingModeProvisioned && !hasCapacity {
+		return fmt.Errorf("billing_mode provisioned requires read_capacity_units and write_capacity_units")
+	}
+	if billingMode != billingModeProvisioned && hasCapacity {
+		return fmt.Errorf("read_capacity_units and write_capacity_units require billing_mode provisioned")
+	}
+	return nil
+}
+
+// validateTableOptionVersions checks that the table options set in the
+// configuration are supported by the server. It is skipped when the cluster cannot
+// be reached, as it may be created in the same apply.
+func validateTableOptionVersions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var options []string
+	for option := range tableOptionVersions {
+		if !d.GetRawConfig().GetAttr(option).IsNull() && d.HasChange(option) {
+			options = append(options, option)
+		}
+	}
+	if len(options) == 0 {
+		return nil
+	}
+	sort.Strings(options)
+
+	providerConfig := meta.(*ProviderConfig)
+	session, err := providerConfig.createSession(nil)
+	if err != nil {
+		log.Printf("[WARN] Unable to validate the options of table %s against the server version: %s", d.Get("name").(string), err)
+		return nil
+	}
+	session.Close()
+
+	for _, option := range options {
+		version := tableOptionVersions[option]
+		if providerConfig.Mode == modeScylla {
+			if !version.scylla {
+				return fmt.Errorf("%s is not supported by Scylla", option)
+			}
+			continue
+		}
+		if supported, ok := releaseVersionAtLeast(providerConfig.ReleaseVersion, version.major, version.minor); ok && !supported {
+			return fmt.Errorf("%s requires Cassandra %d.%d or later, the cluster runs %s", option, version.major, version.minor, providerConfig.ReleaseVersion)
+		}
+	}
+	return nil
+}
+
+// droppedColumns returns the names of the columns in old missing from new, when
+// new is old without them. Otherwise ok is false, the change cannot be applied
+// with ALTER TABLE DROP.
+func droppedColumns(old []interface{}, new []interface{}) (dropped []string, ok bool) {
+	remaining := 0
+	for _, rawColumn := range old {
+		column := rawColumn.(map[string]interface{})
+		if remaining < len(new) && reflect.DeepEqual(column, new[remaining]) {
+			remaining++
+			continue
+		}
+		dropped = append(dropped, column["name"].(string))
+	}
+	return dropped, remaining == len(new)
+}
+
+// forceNewOnColumnChange replaces the table when its columns change, unless
+// columns are only removed and allow_column_drops is set.
+func forceNewOnColumnChange(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("column") || !d.NewValueKnown("column") {
+		return nil
+	}
+	old, new := d.GetChange("column")
+	if _, ok := droppedColumns(old.([]interface{}), new.([]interface{})); ok && d.Get("allow_column_drops").(bool) {
+		return nil
+	}
+	return d.ForceNew("column")
+}
+
+// tableKeys returns the partition and clustering key columns, falling back to the
+// deprecated row_keys and range_keys.
+func tableKeys(d *schema.ResourceData) ([]string, []string) {
+	partitionKeys := listToArray(d.Get("partition_keys"))
+	if len(partitionKeys) == 0 {
+		partitionKeys = setToArray(d.Get("row_keys"))
+	}
+	clusteringKeys := listToArray(d.Get("clustering_keys"))
+	if len(clusteringKeys) == 0 {
+		clusteringKeys = setToArray(d.Get("range_keys"))
+	}
+	return partitionKeys, clusteringKeys
+}
+
+// numericTableOptions are the table options taking a plain number, stored in
+// attributes of the same name.
+var numericTableOptions = []string{"gc_grace_seconds", "default_time_to_live", "bloom_filter_fp_chance", "crc_check_chance", "memtable_flush_period_in_ms", "paxos_grace_seconds"}
+
+// boolTableOptions are the table options taking a boolean, stored in attributes
+// of the same name.
+var boolTableOptions = []string{"allow_auto_snapshot"}
+
+// tableOptionVersions are the minimum Cassandra major and minor versions of the
+// table options introduced after 4.0, and whether Scylla supports them.
+var tableOptionVersions = map[string]struct {
+	major, minor int
+	scylla       bool
+}{
+	"paxos_grace_seconds": {major: 4, minor: 1, scylla: true},
+	"allow_auto_snapshot": {major: 5, minor: 0},
+	"memtable":            {major: 5, minor: 0},
+}
+
+// textTableOptions are the table options taking a string, stored in attributes
+// of the same name.
+var textTableOptions = []string{"read_repair", "memtable"}
+
+const (
+	billingModeOnDemand    = "on_demand"
+	billingModeProvisioned = "provisioned"
+)
+
+// throughputModes maps billing_mode to the throughput modes of Amazon Keyspaces.
+var throughputModes = map[string]string{
+	billingModeOnDemand:    "PAY_PER_REQUEST",
+	billingModeProvisioned: "PROVISIONED",
+}
+
+// customProperties returns the Amazon Keyspaces CUSTOM_PROPERTIES of the table,
+// keyed by property.
+func customProperties(d *schema.ResourceData) map[string]map[string]interface{} {
+	properties := make(map[string]map[string]interface{})
+	if billingMode := d.Get("billing_mode").(string); billingMode != "" {
+		capacityMode := map[string]interface{}{
+			"throughput_mode": throughputModes[billingMode],
+		}
+		if billingMode == billingModeProvisioned {
+			capacityMode["read_capacity_units"] = d.Get("read_capacity_units").(int)
+			capacityMode["write_capacity_units"] = d.Get("write_capacity_units").(int)
+		}
+		properties["capacity_mode"] = capacityMode
+	}
+	if pointInTimeRecovery, ok := d.GetOkExists("point_in_time_recovery"); ok {
+		status := "disabled"
+		if pointInTimeRecovery.(bool) {
+			status = "enabled"
+		}
+		properties["point_in_time_recovery"] = map[string]interface{}{"status": status}
+	}
+	return properties
+}
+
+// customPropertiesLiteral renders the custom properties as a CQL map of maps with
+// sorted keys, numbers are left unquoted.
+func customPropertiesLiteral(properties map[string]map[string]interface{}) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rendered := make([]string, 0, len(names))
+	for _, name := range names {
+		keys := make([]string, 0, len(properties[name]))
+		for key := range properties[name] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		entries := make([]string, 0, len(keys))
+		for _, key := range keys {
+			switch value := properties[name][key].(type) {
+			case string:
+				entries = append(entries, fmt.Sprintf("'%s' : '%s'", key, value))
+			default:
+				entries = append(entries, fmt.Sprintf("'%s' : %v", key, value))
+			}
+		}
+		rendered = append(rendered, fmt.Sprintf("'%s' : { %s }", name, strings.Join(entries, ", ")))
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(rendered, ", "))
+}
+
+// tagQueries returns the statements changing the tags of a table on Amazon
+// Keyspaces from old to new.
+func tagQueries(keyspace string, name string, old map[string]interface{}, new map[string]interface{}) []string {
+	dropped := make(map[string]interface{})
+	for key, value := range old {
+		if _, ok := new[key]; !ok {
+			dropped[key] = value
+		}
+	}
+	added := make(map[string]interface{})
+	for key, value := range new {
+		if oldValue, ok := old[key]; !ok || oldValue != value {
+			added[key] = value
+		}
+	}
+
+	var queries []string
+	if len(dropped) > 0 {
+		queries = append(queries, fmt.Sprintf(`ALTER TABLE %q.%q DROP TAGS %s`, keyspace, name, cqlMapLiteral(dropped)))
+	}
+	if len(added) > 0 {
+		queries = append(queries, fmt.Sprintf(`ALTER TABLE %q.%q ADD TAGS %s`, keyspace, name, cqlMapLiteral(added)))
+	}
+	return queries
+}
+
+// readTags returns the tags of a table on Amazon Keyspaces.
+func readTags(session *gocql.Session, keyspace string, name string) (map[string]string, error) {
+	var tags map[string]string
+	err := session.Query(`SELECT tags FROM system_schema_mcs.tags WHERE keyspace_name = ? AND resource_type = 'table' AND resource_name = ?`, keyspace, name).Scan(&tags)
+	if err == gocql.ErrNotFound {
+		return map[string]string{}, nil
+	}
+	return tags, err
+}
+
+// readCustomProperties returns the Amazon Keyspaces custom properties of a table.
+func readCustomProperties(session *gocql.Session, keyspace string, name string) (map[string]map[string]string, error) {
+	var properties map[string]map[string]string
+	err := session.Query(`SELECT custom_properties FROM system_schema_mcs.tables WHERE keyspace_name = ? AND table_name = ?`, keyspace, name).Scan(&properties)
+	return properties, err
+}
+
+// setCapacityMode sets billing_mode and the capacity units from the capacity_mode
+// custom property of Amazon Keyspaces.
+func setCapacityMode(d *schema.ResourceData, capacityMode map[string]string) {
+	for billingMode, throughputMode := range throughputModes {
+		if capacityMode["throughput_mode"] == throughputMode {
+			d.Set("billing_mode", billingMode)
+		}
+	}
+	if capacityMode["throughput_mode"] != throughputModes[billingModeProvisioned] {
+		d.Set("read_capacity_units", 0)
+		d.Set("write_capacity_units", 0)
+		return
+	}
+	if units, err := strconv.Atoi(capacityMode["read_capacity_units"]); err == nil {
+		d.Set("read_capacity_units", units)
+	}
+	if units, err := strconv.Atoi(capacityMode["write_capacity_units"]); err == nil {
+		d.Set("write_capacity_units", units)
+	}
+}
+
+// cqlMapLiteral renders m as a CQL map literal with sorted keys and text values.
+func cqlMapLiteral(m map[string]interface{}) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := strings.ReplaceAll(fmt.Sprint(m[key]), "'", "''")
+		entries = append(entries, fmt.Sprintf("'%s' : '%s'", strings.ReplaceAll(key, "'", "''"), value))
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(entries, ", "))
+}
+
+// tableOptions returns the WITH options of the table. With changedOnly set only
+// the options changed since the last apply are returned, for ALTER TABLE. Options
+// removed from the configuration are left as they are on the server.
+func tableOptions(d *schema.ResourceData, changedOnly bool) []string {
+	var options []string
+	for _, option := range append(numericTableOptions, boolTableOptions...) {
+		// GetOk cannot tell an explicit 0 apart from unset
+		if value, ok := d.GetOkExists(option); ok && (!changedOnly || d.HasChange(option)) {
+			options = append(options, fmt.Sprintf("%s = %v", option, value))
+		}
+	}
+	for _, option := range textTableOptions {
+		if value, ok := d.GetOk(option); ok && (!changedOnly || d.HasChange(option)) {
+			options = append(options, fmt.Sprintf("%s = '%s'", option, value))
+		}
+	}
+	if changedOnly && d.HasChange("cdc") {
+		options = append(options, fmt.Sprintf("cdc = %t", d.Get("cdc").(bool)))
+	} else if !changedOnly && d.Get("cdc").(bool) {
+		options = append(options, "cdc = true")
+	}
+	if scyllaCDC := d.Get("scylla_cdc").(map[string]interface{}); changedOnly && d.HasChange("scylla_cdc") && len(scyllaCDC) == 0 {
+		options = append(options, "cdc = { 'enabled' : 'false' }")
+	} else if len(scyllaCDC) > 0 && (!changedOnly || d.HasChange("scylla_cdc")) {
+		options = append(options, fmt.Sprintf("cdc = %s", cqlMapLiteral(scyllaCDC)))
+	}
+	if compaction, ok := d.GetOk("compaction"); ok && (!changedOnly || d.HasChange("compaction")) {
+		options = append(options, fmt.Sprintf("compaction = %s", cqlMapLiteral(compaction.(map[string]interface{}))))
+	}
+	if properties := customProperties(d); len(properties) > 0 && (!changedOnly || d.HasChanges("billing_mode", "read_capacity_units", "write_capacity_units", "point_in_time_recovery")) {
+		options = append(options, fmt.Sprintf("CUSTOM_PROPERTIES = %s", customPropertiesLiteral(properties)))
+	}
+	// tags are altered with ADD TAGS and DROP TAGS, see tagQueries
+	if tags := d.Get("tags").(map[string]interface{}); !changedOnly && len(tags) > 0 {
+		options = append(options, fmt.Sprintf("TAGS = %s", cqlMapLiteral(tags)))
+	}
+	return options
+}
+
+// readTableOptions returns the row describing the table in system_schema.tables.
+func readTableOptions(session *gocql.Session, keyspace string, name string) (map[string]interface{}, error) {
+	options := make(map[string]interface{})
+	err := session.Query(`SELECT * FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?`, keyspace, name).MapScan(options)
+	return options, err
+}
+
+// readTableColumns returns the columns of a table with its partition and
+// clustering keys, from system_schema.columns. The columns come key columns first,
+// in key order, then the other columns sorted by name as the server does not
+// record the order they were declared in.
+func readTableColumns(session *gocql.Session, keyspace string, name string) ([]tableColumn, []string, []string, error) {
+	type keyColumn struct {
+		name     string
+		position int
+	}
+	var partitionKeys, clusteringKeys []keyColumn
+	var columns []tableColumn
+
+	iter := session.Query(`SELECT column_name, kind, position, type FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ?`, keyspace, name).Iter()
+	var columnName, kind, columnType string
+	var position int
+	for iter.Scan(&columnName, &kind, &position, &columnType) {
+		columns = append(columns, tableColumn{Name: columnName, Type: columnType})
+		switch kind {
+		case "partition_key":
+			partitionKeys = append(partitionKeys, keyColumn{name: columnName, position: position})
+		case "clustering":
+			clusteringKeys = append(clusteringKeys, keyColumn{name: columnName, position: position})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	keyNames := func(keys []keyColumn) []string {
+		sort.Slice(keys, func(i, j int) bool { return keys[i].position < keys[j].position })
+		names := make([]string, 0, len(keys))
+		for _, key := range keys {
+			names = append(names, key.name)
+		}
+		return names
+	}
+	partitionKeyNames, clusteringKeyNames := keyNames(partitionKeys), keyNames(clusteringKeys)
+
+	order := make(map[string]int, len(partitionKeyNames)+len(clusteringKeyNames))
+	for i, key := range append(append([]string{}, partitionKeyNames...), clusteringKeyNames...) {
+		order[key] = i
+	}
+	sort.SliceStable(columns, func(i, j int) bool {
+		oi, iKey := order[columns[i].Name]
+		oj, jKey := order[columns[j].Name]
+		if iKey || jKey {
+			return iKey && (!jKey || oi < oj)
+		}
+		return columns[i].Name < columns[j].Name
+	})
+	return columns, partitionKeyNames, clusteringKeyNames, nil
+}
+
+var varcharPattern = regexp.MustCompile(`\bvarchar\b`)
+
+// normalizeCQLType returns the form of a CQL type the server reports, so
+// equivalent spellings in the configuration do not show as drift.
+func normalizeCQLType(cqlType string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(cqlType), ""))
+	return varcharPattern.ReplaceAllString(normalized, "text")
+}
+
+// mergeTableColumns returns the columns of the server in the order they are in
+// the state, followed by the columns unknown to the state. Columns whose type
+// only differs in spelling keep the type of the state.
+func mergeTableColumns(configured []tableColumn, actual []tableColumn) []tableColumn {
+	actualTypes := make(map[string]string, len(actual))
+	for _, column := range actual {
+		actualTypes[column.Name] = column.Type
+	}
+
+	merged := make([]tableColumn, 0, len(actual))
+	known := make(map[string]bool, len(configured))
+	for _, column := range configured {
+		actualType, ok := actualTypes[column.Name]
+		if !ok {
+			continue
+		}
+		if normalizeCQLType(actualType) != normalizeCQLType(column.Type) {
+			column.Type = actualType
+		}
+		merged = append(merged, column)
+		known[column.Name] = true
+	}
+	for _, column := range actual {
+		if !known[column.Name] {
+			merged = append(merged, column)
+		}
+	}
+	return merged
+}
+
+// configuredCompaction returns the compaction options of the server restricted to
+// the keys set in the configuration, the server reports every default.
+func configuredCompaction(configured map[string]interface{}, actual map[string]string) map[string]string {
+	compaction := make(map[string]string, len(configured))
+	for key, value := range configured {
+		actualValue, ok := actual[key]
+		if !ok {
+			continue
+		}
+		// the server reports the fully qualified class name
+		if key == "class" && shortStrategyClass(actualValue) == shortStrategyClass(value.(string)) {
+			actualValue = value.(string)
+		}
+		compaction[key] = actualValue
+	}
+	return compaction
+}
+
+// readScyllaCDC returns the CDC options of a table on Scylla.
+func readScyllaCDC(session *gocql.Session, keyspace string, name string) (map[string]string, error) {
+	var cdc map[string]string
+	err := session.Query(`SELECT cdc FROM system_schema.scylla_tables WHERE keyspace_name = ? AND table_name = ?`, keyspace, name).Scan(&cdc)
+	if err == gocql.ErrNotFound {
+		return map[string]string{}, nil
+	}
+	return cdc, err
+}
+
+// configuredScyllaCDC returns the CDC options of the server restricted to the keys
+// set in the configuration, and whether CDC is enabled so enabling it outside of
+// Terraform shows as drift.
+func configuredScyllaCDC(configured map[string]interface{}, actual map[string]string) map[string]string {
+	cdc := make(map[string]string, len(configured))
+	for key := range configured {
+		if value, ok := actual[key]; ok {
+			cdc[key] = value
+		}
+	}
+	if actual["enabled"] == "true" {
+		cdc["enabled"] = "true"
+	}
+	return cdc
+}
+
+// tableSchemaDifferences lists, diff style, how the columns and primary key of an
+// existing table differ from the configured ones. Lines starting with - are only
+// in the configuration, + only in the existing table, ~ differ between both.
+func tableSchemaDifferences(columns []tableColumn, partitionKeys []string, clusteringKeys []string, actualColumns []tableColumn, actualPartitionKeys []string, actualClusteringKeys []string) []string {
+	var differences []string
+	if strings.Join(partitionKeys, ",") != strings.Join(actualPartitionKeys, ",") {
+		differences = append(differences, fmt.Sprintf("~ partition_keys: %v, existing %v", partitionKeys, actualPartitionKeys))
+	}
+	if strings.Join(clusteringKeys, ",") != strings.Join(actualClusteringKeys, ",") {
+		differences = append(differences, fmt.Sprintf("~ clustering_keys: %v, existing %v", clusteringKeys, actualClusteringKeys))
+	}
+
+	actualTypes := make(map[string]string, len(actualColumns))
+	for _, column := range actualColumns {
+		actualTypes[column.Name] = column.Type
+	}
+	configured := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		configured[column.Name] = true
+		actualType, ok := actualTypes[column.Name]
+		switch {
+		case !ok:
+			differences = append(differences, fmt.Sprintf("- column %s %s", column.Name, column.Type))
+		case normalizeCQLType(actualType) != normalizeCQLType(column.Type):
+			differences = append(differences, fmt.Sprintf("~ column %s %s, existing %s", column.Name, column.Type, actualType))
+		}
+	}
+	for _, column := range actualColumns {
+		if !configured[column.Name] {
+			differences = append(differences, fmt.Sprintf("+ column %s %s", column.Name, column.Type))
+		}
+	}
+	return differences
+}
+
+// waitForTableStatus polls Amazon Keyspaces, which creates and drops tables
+// asynchronously, until the table is ACTIVE or, when status is empty, gone.
+func waitForTableStatus(ctx context.Context, session *gocql.Session, keyspace string, name string, status string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := time.Second
+	for {
+		var actual string
+		err := session.Query(`SELECT status FROM system_schema_mcs.tables WHERE keyspace_name = ? AND table_name = ?`, keyspace, name).WithContext(ctx).Scan(&actual)
+		if err != nil && err != gocql.ErrNotFound && ctx.Err() == nil {
+			return err
+		}
+		if actual == status && ctx.Err() == nil {
+			return nil
+		}
+		log.Printf("Table '%s' in '%s' is '%s', waiting", name, keyspace, actual)
+
+		select {
+		case <-ctx.Done():
+			if status == "" {
+				return fmt.Errorf("table %s in %s was not deleted within %s", name, keyspace, timeout)
+			}
+			return fmt.Errorf("table %s in %s did not become %s within %s", name, keyspace, status, timeout)
+		case <-time.After(backoff):
+		}
+		if backoff < 10*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func generateCreateTableQueryString(keyspace string, name string, ifNotExists bool, columns []tableColumn, partitionKeys []string, clusteringKeys []string, options []string) (string, error) {
+	if len(partitionKeys) == 0 {
+		return "", fmt.Errorf("table %s needs at least one partition key", name)
+	}
+
+	definitions := make([]string, 0, len(columns)+1)
+	for _, column := range columns {
+		definitions = append(definitions, fmt.Sprintf(`%q %s`, column.Name, column.Type))
+	}
+
+	quotedPartitionKeys := make([]string, 0, len(partitionKeys))
+	for _, key := range partitionKeys {
+		quotedPartitionKeys = append(quotedPartitionKeys, fmt.Sprintf("%q", key))
+	}
+	primaryKey := []string{fmt.Sprintf("(%s)", strings.Join(quotedPartitionKeys, ", "))}
+	for _, key := range clusteringKeys {
+		primaryKey = append(primaryKey, fmt.Sprintf("%q", key))
+	}
+	definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(primaryKey, ", ")))
+
+	action := "CREATE TABLE"
+	if ifNotExists {
+		action = "CREATE TABLE IF NOT EXISTS"
+	}
+	query := fmt.Sprintf(`%s %q.%q (%s)`, action, unquoteIdentifier(keyspace), name, strings.Join(definitions, ", "))
+	if len(options) > 0 {
+		query += " WITH " + strings.Join(options, " AND ")
+	}
+	return query, nil
+}
+
 func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var err error
 	name := d.Get("name").(string)
 	keyspaceName := d.Get("keyspace").(string)
-	attributes := d.Get("attribute").(*schema.Set)
-	rowKeys := setToArray(d.Get("row_keys"))
-	rangeKeys := setToArray(d.Get("range_keys"))
+	partitionKeys, clusteringKeys := tableKeys(d)
 	var diags diag.Diagnostics
 
-	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
+	columns := tableColumns(d)
 
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	gocqltable.SetDefaultSession(session)
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
+	providerConfig := meta.(*ProviderConfig)
+	adoptExisting := providerConfig.AdoptExisting || d.Get("adopt_existing").(bool)
+	query, err := generateCreateTableQueryString(keyspaceName, name, adoptExisting, columns, partitionKeys, clusteringKeys, tableOptions(d, false))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	log.Printf("Creating table '%s' in '%s' with obj: %v ", name, keyspaceName, attributes)
-
-	keyspace := gocqltable.NewKeyspace(keyspaceName)
-	resourceTable := keyspace.NewTable(
-		name,
-		rowKeys,
-		rangeKeys,
-		attributes,
-	)
+	log.Printf("Creating table '%s' in '%s'", name, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
 
-	err = resourceTable.Create()
-	if err != nil {
-		return diag.FromErr(err)
+	if providerConfig.AmazonKeyspaces {
+		if err := waitForTableStatus(ctx, session, unquoteIdentifier(keyspaceName), name, "ACTIVE", d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	d.SetId(name)
-	d.Set("name", name)
-	d.Set("keyspace", keyspaceName)
-	d.Set("row_keys", rowKeys)
-	d.Set("range_keys", rangeKeys)
-	d.Set("attributes", attributes)
+	if adoptExisting {
+		actualColumns, actualPartitionKeys, actualClusteringKeys, err := readTableColumns(session, unquoteIdentifier(keyspaceName), name)
+		if err != nil {
+			return errorDiagnostics(err, "", nil)
+		}
+		differences := tableSchemaDifferences(columns, partitionKeys, clusteringKeys, actualColumns, actualPartitionKeys, actualClusteringKeys)
+		if len(differences) > 0 {
+			return diag.Errorf("table %s in %s already exists with a different schema, adjust the configuration or drop the table:\n%s", name, keyspaceName, strings.Join(differences, "\n"))
+		}
+	}
 
+	d.SetId(tableID(keyspaceName, name))
+	d.Set("column", flattenTableColumns(columns))
 	diags = append(diags, resourceTableRead(ctx, d, meta)...)
 	return diags
 }
@@ -133,85 +1021,173 @@ func resourceTableCreate(ctx context.Context, d *schema.ResourceData, meta inter
 func resourceTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	keyspaceName := d.Get("keyspace").(string)
-	attributes := d.Get("attribute").(*schema.Set)
-	rowKeys := setToArray(d.Get("row_keys"))
-	rangeKeys := setToArray(d.Get("range_keys"))
 	var diags diag.Diagnostics
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
-
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	keyspaceMetadata, err := session.KeyspaceMetadata(keyspaceName)
-	if err != nil {
-		return diag.FromErr(err)
+	keyspaceMetadata, err := session.KeyspaceMetadata(unquoteIdentifier(keyspaceName))
+	if err == gocql.ErrKeyspaceDoesNotExist {
+		log.Printf("[WARN] Keyspace '%s' of table '%s' no longer exists, removing it from the state", keyspaceName, name)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
 	}
 
-	tableExists := false
-	for _, tbl := range keyspaceMetadata.Tables {
-		if tbl.Name == name {
-			log.Printf("Found table '%s' in '%s'", name, keyspaceName)
-			tableExists = true
-			break
-		}
+	if _, ok := keyspaceMetadata.Tables[name]; !ok {
+		log.Printf("[WARN] Table '%s' no longer exists in '%s', removing it from the state", name, keyspaceName)
+		d.SetId("")
+		return nil
 	}
 
-	d.SetId(name)
-	if tableExists {
-		d.Set("name", name)
-		d.Set("keyspace", keyspaceName)
-		d.Set("attributes", attributes)
-		d.Set("row_keys", rowKeys)
-		d.Set("range_keys", rangeKeys)
+	log.Printf("Found table '%s' in '%s'", name, keyspaceName)
+	// tables created before the keyspace was part of the identifier are migrated here
+	d.SetId(tableID(keyspaceName, name))
+	if err := setIdentity(d, map[string]string{"keyspace": keyspaceName, "name": name}); err != nil {
+		return diag.FromErr(err)
 	}
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("allow_column_drops", d.Get("allow_column_drops").(bool))
+	d.Set("adopt_existing", d.Get("adopt_existing").(bool))
 
+	columns, partitionKeys, clusteringKeys, err := readTableColumns(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		return errorDiagnostics(err, "", nil)
+	}
+	d.Set("column", flattenTableColumns(mergeTableColumns(tableColumns(d), columns)))
+	// keep the deprecated key attributes when they are in use
+	if d.Get("row_keys").(*schema.Set).Len() > 0 {
+		d.Set("row_keys", partitionKeys)
+	} else {
+		d.Set("partition_keys", partitionKeys)
+	}
+	if d.Get("range_keys").(*schema.Set).Len() > 0 {
+		d.Set("range_keys", clusteringKeys)
+	} else {
+		d.Set("clustering_keys", clusteringKeys)
+	}
+
+	options, err := readTableOptions(session, unquoteIdentifier(keyspaceName), name)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the options of table '%s' in '%s': %s", name, keyspaceName, err)
+		return diags
+	}
+	for _, option := range append(append(numericTableOptions, boolTableOptions...), textTableOptions...) {
+		if value, ok := options[option]; ok {
+			d.Set(option, value)
+		}
+	}
+	if id, ok := options["id"].(gocql.UUID); ok {
+		d.Set("table_id", id.String())
+	}
+	if cdc, ok := options["cdc"].(bool); ok {
+		d.Set("cdc", cdc)
+	}
+	if providerConfig.Mode == modeScylla {
+		if cdc, err := readScyllaCDC(session, unquoteIdentifier(keyspaceName), name); err != nil {
+			log.Printf("[WARN] Unable to read the CDC options of table '%s' in '%s': %s", name, keyspaceName, err)
+		} else {
+			d.Set("scylla_cdc", configuredScyllaCDC(d.Get("scylla_cdc").(map[string]interface{}), cdc))
+		}
+	}
+	// system_schema_mcs only exists on Amazon Keyspaces
+	_, pointInTimeRecoverySet := d.GetOkExists("point_in_time_recovery")
+	if d.Get("billing_mode").(string) != "" || pointInTimeRecoverySet {
+		if properties, err := readCustomProperties(session, unquoteIdentifier(keyspaceName), name); err != nil {
+			log.Printf("[WARN] Unable to read the custom properties of table '%s' in '%s': %s", name, keyspaceName, err)
+		} else {
+			if d.Get("billing_mode").(string) != "" {
+				setCapacityMode(d, properties["capacity_mode"])
+			}
+			if pointInTimeRecoverySet {
+				d.Set("point_in_time_recovery", properties["point_in_time_recovery"]["status"] == "enabled")
+			}
+		}
+	}
+	if len(d.Get("tags").(map[string]interface{})) > 0 {
+		if tags, err := readTags(session, unquoteIdentifier(keyspaceName), name); err != nil {
+			log.Printf("[WARN] Unable to read the tags of table '%s' in '%s': %s", name, keyspaceName, err)
+		} else {
+			d.Set("tags", tags)
+		}
+	}
+	if compaction, ok := d.GetOk("compaction"); ok {
+		if actual, ok := options["compaction"].(map[string]string); ok {
+			d.Set("compaction", configuredCompaction(compaction.(map[string]interface{}), actual))
+		}
+	}
 	return diags
 }
 
 func resourceTableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 	keyspaceName := d.Get("keyspace").(string)
-	attributes := d.Get("attribute").(*schema.Set)
-	rowKeys := setToArray(d.Get("row_keys"))
-	rangeKeys := setToArray(d.Get("range_keys"))
 	var diags diag.Diagnostics
 
 	providerConfig := meta.(*ProviderConfig)
-	cluster := providerConfig.Cluster
-
-	start := time.Now()
-	session, sessionCreateError := cluster.CreateSession()
-	gocqltable.SetDefaultSession(session)
-	elapsed := time.Since(start)
-	log.Printf("Getting a session took %s", elapsed)
-
+	session, sessionCreateError := providerConfig.createSession(d)
 	if sessionCreateError != nil {
-		return diag.FromErr(sessionCreateError)
+		return errorDiagnostics(sessionCreateError, "", nil)
 	}
 	defer session.Close()
 
-	keyspace := gocqltable.NewKeyspace(keyspaceName)
-	log.Printf("Deleting table '%s' with obj: %v ", name, attributes)
-	resourceTable := keyspace.NewTable(
-		name,
-		rowKeys,
-		rangeKeys,
-		attributes,
-	)
+	log.Printf("Deleting table '%s' in '%s'", name, keyspaceName)
+	query := fmt.Sprintf(`DROP TABLE IF EXISTS %q.%q`, unquoteIdentifier(keyspaceName), name)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	if providerConfig.AmazonKeyspaces {
+		if err := waitForTableStatus(ctx, session, unquoteIdentifier(keyspaceName), name, "", d.Timeout(schema.TimeoutDelete)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diags
+}
 
-	err := resourceTable.Drop()
-	if err != nil {
-		return diag.FromErr(err)
+func resourceTableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	// keys and columns force a new resource, except columns dropped with
+	// allow_column_drops, the table options are altered in place
+	var queries []string
+	if d.HasChange("column") {
+		old, new := d.GetChange("column")
+		dropped, _ := droppedColumns(old.([]interface{}), new.([]interface{}))
+		for _, column := range dropped {
+			queries = append(queries, fmt.Sprintf(`ALTER TABLE %q.%q DROP %q`, unquoteIdentifier(keyspaceName), name, column))
+		}
+	}
+	if options := tableOptions(d, true); len(options) > 0 {
+		queries = append(queries, fmt.Sprintf(`ALTER TABLE %q.%q WITH %s`, unquoteIdentifier(keyspaceName), name, strings.Join(options, " AND ")))
+	}
+	if d.HasChange("tags") {
+		old, new := d.GetChange("tags")
+		queries = append(queries, tagQueries(unquoteIdentifier(keyspaceName), name, old.(map[string]interface{}), new.(map[string]interface{}))...)
+	}
+
+	if len(queries) > 0 {
+		providerConfig := meta.(*ProviderConfig)
+		session, sessionCreateError := providerConfig.createSession(d)
+		if sessionCreateError != nil {
+			return errorDiagnostics(sessionCreateError, "", nil)
+		}
+		defer session.Close()
+
+		for _, query := range queries {
+			if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+				return errorDiagnostics(err, query, nil)
+			}
+		}
 	}
 
+	diags = append(diags, resourceTableRead(ctx, d, meta)...)
 	return diags
 }
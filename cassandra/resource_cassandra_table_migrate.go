@@ -0,0 +1,80 @@
+package cassandra
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceCassandraTableV0 is the schema of cassandra_table before the column
+// blocks were introduced, the columns were described by attribute blocks only.
+func resourceCassandraTableV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keyspace": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"attribute": {
+				Type: schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+				Required: true,
+			},
+			"row_keys": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"range_keys": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"partition_keys": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"clustering_keys": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+			"consistency": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// resourceTableStateUpgradeV0 derives the column blocks from the attribute blocks,
+// which are kept so configurations still using them plan no changes.
+func resourceTableStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawAttributes, _ := rawState["attribute"].([]interface{})
+	columns := make([]tableColumn, 0, len(rawAttributes))
+	for _, rawAttribute := range rawAttributes {
+		if attribute, ok := rawAttribute.(map[string]interface{}); ok {
+			columns = append(columns, attributeToColumn(attribute))
+		}
+	}
+	log.Printf("[DEBUG] Upgrading cassandra_table %v state with columns %v", rawState["name"], columns)
+	rawState["column"] = flattenTableColumns(columns)
+	return rawState, nil
+}
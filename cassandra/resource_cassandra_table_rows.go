@@ -0,0 +1,468 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraTableRows() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage a set of rows of a table, e.g. reference or lookup data. Rows are identified by their primary key, other rows of the table are left untouched",
+		CreateContext: resourceTableRowsCreate,
+		ReadContext:   resourceTableRowsRead,
+		UpdateContext: resourceTableRowsUpdate,
+		DeleteContext: resourceTableRowsDelete,
+		Schema: map[string]*schema.Schema{
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace of the table, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"table": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the table",
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsJSON},
+				Required:    true,
+				Description: "Rows as JSON objects, as accepted by INSERT JSON, e.g. jsonencode({ id = 1, name = \"one\" }). Each row must set every primary key column",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// tableRow is a row decoded from its JSON representation.
+type tableRow map[string]interface{}
+
+// decodeTableRow decodes a row as accepted by INSERT JSON or returned by SELECT
+// JSON. Keys are resolved to column names, quoted keys are case sensitive, and
+// numbers are kept as json.Number so that large integers and decimals stay exact.
+func decodeTableRow(rawRow string) (tableRow, error) {
+	decoder := json.NewDecoder(strings.NewReader(rawRow))
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	row := make(tableRow, len(decoded))
+	for key, value := range decoded {
+		row[unquoteIdentifier(key)] = value
+	}
+	return row, nil
+}
+
+func decodeTableRows(rawRows []interface{}) ([]tableRow, error) {
+	rows := make([]tableRow, 0, len(rawRows))
+	for i, rawRow := range rawRows {
+		row, err := decodeTableRow(rawRow.(string))
+		if err != nil {
+			return nil, fmt.Errorf("row %d is not a JSON object: %w", i, err)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+var cqlTimestampLayouts = []string{
+	"2006-01-02 15:04:05Z0700",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04Z0700",
+	"2006-01-02 15:04",
+	"2006-01-02Z0700",
+	"2006-01-02",
+}
+
+// parseCQLType splits a CQL type into its name and type parameters, e.g.
+// map<text, int> into map and [text int]. frozen<> is dropped.
+func parseCQLType(cqlType string) (string, []string) {
+	cqlType = strings.TrimSpace(cqlType)
+	start := strings.Index(cqlType, "<")
+	if start < 0 || !strings.HasSuffix(cqlType, ">") {
+		return strings.ToLower(cqlType), nil
+	}
+	name := strings.ToLower(strings.TrimSpace(cqlType[:start]))
+	parameters := cqlType[start+1 : len(cqlType)-1]
+	if name == "frozen" {
+		return parseCQLType(parameters)
+	}
+	return name, splitTypeList(parameters)
+}
+
+// normalizeColumnValue returns value, decoded from JSON, in a canonical form for
+// the CQL type of its column, so that values rendered differently by SELECT JSON
+// than in the configuration compare equal, e.g. timestamps, uuids or sets.
+// Values of other types, e.g. user defined types, are returned as is.
+func normalizeColumnValue(cqlType string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	name, parameters := parseCQLType(cqlType)
+	text, isText := value.(string)
+	switch name {
+	case "list", "set", "tuple":
+		elements, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		normalized := make([]interface{}, len(elements))
+		for i, element := range elements {
+			elementType := ""
+			if name == "tuple" && i < len(parameters) {
+				elementType = parameters[i]
+			} else if name != "tuple" && len(parameters) == 1 {
+				elementType = parameters[0]
+			}
+			normalized[i] = normalizeColumnValue(elementType, element)
+		}
+		if name == "set" {
+			sort.Slice(normalized, func(i, j int) bool {
+				a, _ := json.Marshal(normalized[i])
+				b, _ := json.Marshal(normalized[j])
+				return string(a) < string(b)
+			})
+		}
+		return normalized
+	case "map":
+		entries, ok := value.(map[string]interface{})
+		if !ok || len(parameters) != 2 {
+			return value
+		}
+		normalized := make(map[string]interface{}, len(entries))
+		for key, entry := range entries {
+			normalizedKey, _ := json.Marshal(normalizeColumnValue(parameters[0], key))
+			normalized[string(normalizedKey)] = normalizeColumnValue(parameters[1], entry)
+		}
+		return normalized
+	case "tinyint", "smallint", "int", "bigint", "varint", "counter", "float", "double", "decimal":
+		if number, ok := new(big.Float).SetPrec(256).SetString(fmt.Sprint(value)); ok {
+			return number.Text('g', -1)
+		}
+	case "timestamp":
+		// milliseconds since the epoch
+		if number, ok := value.(json.Number); ok {
+			if millis, err := number.Int64(); err == nil {
+				return millis
+			}
+		}
+		for _, layout := range cqlTimestampLayouts {
+			if t, err := time.Parse(layout, text); isText && err == nil {
+				return t.UnixMilli()
+			}
+		}
+	case "time":
+		// nanoseconds since midnight
+		if number, ok := value.(json.Number); ok {
+			if nanos, err := number.Int64(); err == nil {
+				return nanos
+			}
+		}
+		if t, err := time.Parse("15:04:05", text); isText && err == nil {
+			return int64(t.Hour())*int64(time.Hour) + int64(t.Minute())*int64(time.Minute) + int64(t.Second())*int64(time.Second) + int64(t.Nanosecond())
+		}
+	case "uuid", "timeuuid", "blob":
+		if isText {
+			return strings.ToLower(text)
+		}
+	case "inet":
+		if ip := net.ParseIP(text); isText && ip != nil {
+			return ip.String()
+		}
+	case "boolean":
+		if parsed, err := strconv.ParseBool(text); isText && err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+// tableRowsEqual reports whether the columns of configured hold the same values in
+// actual, compared by the CQL types of the columns.
+func tableRowsEqual(columnTypes map[string]string, configured tableRow, actual tableRow) bool {
+	for column, value := range configured {
+		if !reflect.DeepEqual(normalizeColumnValue(columnTypes[column], value), normalizeColumnValue(columnTypes[column], actual[column])) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowKey returns the JSON encoded values of the primary key columns of row, as
+// bound to fromJson() in WHERE clauses.
+func rowKey(row tableRow, keyColumns []string) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(keyColumns))
+	for _, column := range keyColumns {
+		value, ok := row[column]
+		if !ok {
+			return nil, fmt.Errorf("row is missing primary key column %s", column)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(encoded))
+	}
+	return values, nil
+}
+
+// rowKeyWhereClause returns the WHERE clause selecting a row by its primary key.
+func rowKeyWhereClause(keyColumns []string) string {
+	conditions := make([]string, 0, len(keyColumns))
+	for _, column := range keyColumns {
+		conditions = append(conditions, fmt.Sprintf("%q = fromJson(?)", column))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// readPrimaryKey returns the partition and clustering key columns of the table,
+// none when the table does not exist.
+func readPrimaryKey(session *gocql.Session, keyspace string, table string) ([]string, error) {
+	_, partitionKeys, clusteringKeys, err := readTableColumns(session, keyspace, table)
+	if err != nil {
+		return nil, err
+	}
+	return append(partitionKeys, clusteringKeys...), nil
+}
+
+// upsertTableRows inserts rows, INSERT overwrites rows with the same primary key.
+// using is appended to the statement, e.g. a Scylla USING TIMEOUT clause.
+func upsertTableRows(ctx context.Context, session *gocql.Session, keyspace string, table string, using string, rawRows []interface{}) diag.Diagnostics {
+	query := fmt.Sprintf(`INSERT INTO %q.%q JSON ?%s`, keyspace, table, using)
+	for i, rawRow := range rawRows {
+		if err := session.Query(query, rawRow.(string)).WithContext(ctx).Exec(); err != nil {
+			return errorDiagnostics(err, query, cty.GetAttrPath("rows").IndexInt(i))
+		}
+	}
+	return nil
+}
+
+func deleteTableRows(ctx context.Context, session *gocql.Session, keyspace string, table string, using string, keyColumns []string, rows []tableRow) diag.Diagnostics {
+	query := fmt.Sprintf(`DELETE FROM %q.%q%s WHERE %s`, keyspace, table, using, rowKeyWhereClause(keyColumns))
+	for _, row := range rows {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := session.Query(query, key...).WithContext(ctx).Exec(); err != nil {
+			return errorDiagnostics(err, query, nil)
+		}
+	}
+	return nil
+}
+
+func resourceTableRowsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Inserting rows into '%s' in '%s'", table, keyspaceName)
+	if diags := upsertTableRows(ctx, session, unquoteIdentifier(keyspaceName), table, providerConfig.usingTimeoutClause(), d.Get("rows").([]interface{})); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s.%s", keyspaceName, table))
+	diags = append(diags, resourceTableRowsRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceTableRowsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	columns, partitionKeys, clusteringKeys, err := readTableColumns(session, unquoteIdentifier(keyspaceName), table)
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("table"))
+	}
+	keyColumns := append(partitionKeys, clusteringKeys...)
+	columnTypes := make(map[string]string, len(columns))
+	for _, column := range columns {
+		columnTypes[column.Name] = column.Type
+	}
+	if len(keyColumns) == 0 {
+		log.Printf("[WARN] Table '%s' no longer exists in '%s', removing its rows from the state", table, keyspaceName)
+		d.SetId("")
+		return nil
+	}
+
+	rawRows := d.Get("rows").([]interface{})
+	rows, err := decodeTableRows(rawRows)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	actualRows := make([]interface{}, 0, len(rows))
+	for i, row := range rows {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("row %d: %w", i, err))
+		}
+
+		// only the columns of the configuration are compared
+		columns := make([]string, 0, len(row))
+		for _, column := range sortedKeys(row) {
+			columns = append(columns, fmt.Sprintf("%q", column))
+		}
+		query := fmt.Sprintf(`SELECT JSON %s FROM %q.%q WHERE %s`, strings.Join(columns, ", "), unquoteIdentifier(keyspaceName), table, rowKeyWhereClause(keyColumns))
+		var actualJSON string
+		err = session.Query(query, key...).WithContext(ctx).Scan(&actualJSON)
+		if err == gocql.ErrNotFound {
+			log.Printf("[WARN] Row %d no longer exists in '%s', removing it from the state", i, table)
+			continue
+		} else if err != nil {
+			return errorDiagnostics(err, query, nil)
+		}
+
+		actual, err := decodeTableRow(actualJSON)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// keep the configured formatting unless the values differ
+		if tableRowsEqual(columnTypes, row, actual) {
+			actualRows = append(actualRows, rawRows[i])
+		} else {
+			actualRows = append(actualRows, actualJSON)
+		}
+	}
+
+	d.Set("keyspace", keyspaceName)
+	d.Set("table", table)
+	d.Set("rows", actualRows)
+	return diags
+}
+
+func resourceTableRowsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+	var diags diag.Diagnostics
+
+	if d.HasChange("rows") {
+		providerConfig := meta.(*ProviderConfig)
+		session, sessionCreateError := providerConfig.createSession(d)
+		if sessionCreateError != nil {
+			return errorDiagnostics(sessionCreateError, "", nil)
+		}
+		defer session.Close()
+
+		keyColumns, err := readPrimaryKey(session, unquoteIdentifier(keyspaceName), table)
+		if err != nil {
+			return errorDiagnostics(err, "", cty.GetAttrPath("table"))
+		} else if len(keyColumns) == 0 {
+			return diag.Errorf("table %s does not exist in %s", table, keyspaceName)
+		}
+
+		old, new := d.GetChange("rows")
+		oldRows, err := decodeTableRows(old.([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		newRows, err := decodeTableRows(new.([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		removed, err := removedTableRows(oldRows, newRows, keyColumns)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("Updating rows of '%s' in '%s', deleting %d", table, keyspaceName, len(removed))
+		if diags := deleteTableRows(ctx, session, unquoteIdentifier(keyspaceName), table, providerConfig.usingTimeoutClause(), keyColumns, removed); diags.HasError() {
+			return diags
+		}
+		if diags := upsertTableRows(ctx, session, unquoteIdentifier(keyspaceName), table, providerConfig.usingTimeoutClause(), new.([]interface{})); diags.HasError() {
+			return diags
+		}
+	}
+
+	diags = append(diags, resourceTableRowsRead(ctx, d, meta)...)
+	return diags
+}
+
+// removedTableRows returns the rows of old whose primary key is not in new.
+func removedTableRows(old []tableRow, new []tableRow, keyColumns []string) ([]tableRow, error) {
+	keys := make(map[string]bool, len(new))
+	for _, row := range new {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return nil, err
+		}
+		keys[fmt.Sprint(key)] = true
+	}
+
+	var removed []tableRow
+	for _, row := range old {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return nil, err
+		}
+		if !keys[fmt.Sprint(key)] {
+			removed = append(removed, row)
+		}
+	}
+	return removed, nil
+}
+
+func resourceTableRowsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyspaceName := d.Get("keyspace").(string)
+	table := d.Get("table").(string)
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	keyColumns, err := readPrimaryKey(session, unquoteIdentifier(keyspaceName), table)
+	if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("table"))
+	}
+	if len(keyColumns) == 0 {
+		log.Printf("[WARN] Table '%s' no longer exists in '%s', its rows are gone", table, keyspaceName)
+		return nil
+	}
+	rows, err := decodeTableRows(d.Get("rows").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Deleting %d rows from '%s' in '%s'", len(rows), table, keyspaceName)
+	return deleteTableRows(ctx, session, unquoteIdentifier(keyspaceName), table, providerConfig.usingTimeoutClause(), keyColumns, rows)
+}
@@ -0,0 +1,132 @@
+package cassandra
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRowKey(t *testing.T) {
+	key, err := rowKey(tableRow{"tenant": "acme", "id": 1.0, "name": "one"}, []string{"tenant", "id"})
+	if err != nil || !reflect.DeepEqual(key, []interface{}{`"acme"`, "1"}) {
+		t.Errorf("expected the JSON encoded key, got %v (%v)", key, err)
+	}
+	if _, err := rowKey(tableRow{"id": 1.0}, []string{"tenant", "id"}); err == nil {
+		t.Error("expected a row missing a key column to fail")
+	}
+}
+
+func TestRemovedTableRows(t *testing.T) {
+	old := []tableRow{{"id": 1.0, "name": "one"}, {"id": 2.0, "name": "two"}}
+	new := []tableRow{{"id": 1.0, "name": "uno"}, {"id": 3.0, "name": "three"}}
+	removed, err := removedTableRows(old, new, []string{"id"})
+	if err != nil || !reflect.DeepEqual(removed, []tableRow{{"id": 2.0, "name": "two"}}) {
+		t.Errorf("expected row 2 to be removed, got %v (%v)", removed, err)
+	}
+}
+
+func TestTableRowsEqual(t *testing.T) {
+	columnTypes := map[string]string{
+		"id":       "int",
+		"MixedId":  "uuid",
+		"created":  "timestamp",
+		"tags":     "set<text>",
+		"scores":   "frozen<map<int, double>>",
+		"address":  "inet",
+		"starts":   "time",
+		"amount":   "decimal",
+		"children": "list<frozen<set<int>>>",
+	}
+	configured, err := decodeTableRow(`{
+		"ID": 1,
+		"\"MixedId\"": "6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"created": "2024-01-02T03:04:05Z",
+		"tags": ["b", "a"],
+		"scores": {"1": 1.5, "2": "2"},
+		"address": "::ffff:10.0.0.1",
+		"starts": "08:30:00",
+		"amount": "10.50",
+		"children": [[2, 1]]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := decodeTableRow(`{
+		"id": 1,
+		"\"MixedId\"": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"created": "2024-01-02 03:04:05.000Z",
+		"tags": ["a", "b"],
+		"scores": {"1": 1.5, "2": 2.0},
+		"address": "10.0.0.1",
+		"starts": "08:30:00.000000000",
+		"amount": 10.5,
+		"children": [[1, 2]]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tableRowsEqual(columnTypes, configured, actual) {
+		t.Errorf("expected %v to equal %v", configured, actual)
+	}
+
+	actual["created"] = "2024-01-02 03:04:06.000Z"
+	if tableRowsEqual(columnTypes, configured, actual) {
+		t.Error("expected a different timestamp to be detected")
+	}
+	actual["created"] = "2024-01-02 03:04:05.000Z"
+	actual["tags"] = []interface{}{"a", "c"}
+	if tableRowsEqual(columnTypes, configured, actual) {
+		t.Error("expected a different set to be detected")
+	}
+}
+
+func TestAccCassandraTableRows_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraTableRowsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_table_rows.countries", "rows.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCassandraTableRowsConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "table_rows_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_table" "countries" {
+  name           = "countries"
+  keyspace       = cassandra_keyspace.keyspace.name
+  partition_keys = ["code"]
+
+  column {
+    name = "code"
+    type = "text"
+  }
+
+  column {
+    name = "name"
+    type = "text"
+  }
+}
+
+resource "cassandra_table_rows" "countries" {
+  keyspace = cassandra_keyspace.keyspace.name
+  table    = cassandra_table.countries.name
+  rows = [
+    jsonencode({ code = "fr", name = "France" }),
+    jsonencode({ code = "de", name = "Germany" }),
+  ]
+}
+`
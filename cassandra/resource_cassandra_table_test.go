@@ -0,0 +1,210 @@
+package cassandra
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGenerateCreateTableQueryString(t *testing.T) {
+	columns := []tableColumn{
+		{Name: "tenant", Type: "text"},
+		{Name: "bucket", Type: "int"},
+		{Name: "created", Type: "timestamp"},
+		{Name: "payload", Type: "blob"},
+	}
+	query, err := generateCreateTableQueryString("some_keyspace", "events", false, columns, []string{"tenant", "bucket"}, []string{"created"}, []string{"compaction = { 'class' : 'LeveledCompactionStrategy' }"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `CREATE TABLE "some_keyspace"."events" ("tenant" text, "bucket" int, "created" timestamp, "payload" blob, PRIMARY KEY (("tenant", "bucket"), "created")) WITH compaction = { 'class' : 'LeveledCompactionStrategy' }`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+
+	if _, err := generateCreateTableQueryString("some_keyspace", "events", false, columns, nil, nil, nil); err == nil {
+		t.Error("expected an error for a table without partition key")
+	}
+}
+
+func TestResourceTableImport(t *testing.T) {
+	resource := resourceCassandraTableSpace()
+	byIdentity := schema.TestResourceDataWithIdentityRaw(t, resource.Schema, resource.Identity.SchemaFunc(), map[string]string{"keyspace": "shop", "name": "users"})
+	byID := schema.TestResourceDataRaw(t, resource.Schema, nil)
+	byID.SetId("shop.users")
+	for _, d := range []*schema.ResourceData{byIdentity, byID} {
+		imported, err := resourceTableImport(context.Background(), d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id, keyspace, name := imported[0].Id(), imported[0].Get("keyspace"), imported[0].Get("name"); id != "shop.users" || keyspace != "shop" || name != "users" {
+			t.Errorf("expected shop.users, got ID %s, keyspace %s and name %s", id, keyspace, name)
+		}
+	}
+}
+
+func TestResourceTableStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name":     "events",
+		"keyspace": "some_keyspace",
+		"attribute": []interface{}{
+			map[string]interface{}{"name": "id", "type": "S"},
+			map[string]interface{}{"name": "amount", "type": "N"},
+		},
+	}
+	upgraded, err := resourceTableStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"name": "id", "type": "text"},
+		map[string]interface{}{"name": "amount", "type": "decimal"},
+	}
+	if !reflect.DeepEqual(upgraded["column"], expected) {
+		t.Errorf("expected columns %v, got %v", expected, upgraded["column"])
+	}
+	if upgraded["attribute"] == nil {
+		t.Error("expected the attribute blocks to be kept")
+	}
+}
+
+func TestConfiguredCompaction(t *testing.T) {
+	configured := map[string]interface{}{
+		"class":                  "TimeWindowCompactionStrategy",
+		"compaction_window_unit": "DAYS",
+	}
+	actual := map[string]string{
+		"class":                  "org.apache.cassandra.db.compaction.TimeWindowCompactionStrategy",
+		"compaction_window_unit": "HOURS",
+		"max_threshold":          "32",
+	}
+	expected := map[string]string{
+		"class":                  "TimeWindowCompactionStrategy",
+		"compaction_window_unit": "HOURS",
+	}
+	if compaction := configuredCompaction(configured, actual); !reflect.DeepEqual(compaction, expected) {
+		t.Errorf("expected %v, got %v", expected, compaction)
+	}
+}
+
+func TestDroppedColumns(t *testing.T) {
+	id := map[string]interface{}{"name": "id", "type": "text"}
+	email := map[string]interface{}{"name": "email", "type": "text"}
+	age := map[string]interface{}{"name": "age", "type": "int"}
+
+	dropped, ok := droppedColumns([]interface{}{id, email, age}, []interface{}{id, age})
+	if !ok || !reflect.DeepEqual(dropped, []string{"email"}) {
+		t.Errorf("expected email to be dropped, got %v (%t)", dropped, ok)
+	}
+
+	if _, ok := droppedColumns([]interface{}{id, email}, []interface{}{email, id}); ok {
+		t.Error("expected reordered columns not to be droppable")
+	}
+	if _, ok := droppedColumns([]interface{}{id}, []interface{}{id, age}); ok {
+		t.Error("expected added columns not to be droppable")
+	}
+}
+
+func TestMergeTableColumns(t *testing.T) {
+	configured := []tableColumn{
+		{Name: "id", Type: "uuid"},
+		{Name: "name", Type: "varchar"},
+		{Name: "tags", Type: "set<text>"},
+		{Name: "dropped", Type: "int"},
+	}
+	actual := []tableColumn{
+		{Name: "id", Type: "uuid"},
+		{Name: "added", Type: "int"},
+		{Name: "name", Type: "text"},
+		{Name: "tags", Type: "list<text>"},
+	}
+
+	expected := []tableColumn{
+		{Name: "id", Type: "uuid"},
+		{Name: "name", Type: "varchar"},
+		{Name: "tags", Type: "list<text>"},
+		{Name: "added", Type: "int"},
+	}
+	if merged := mergeTableColumns(configured, actual); !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+}
+
+func TestNormalizeCQLType(t *testing.T) {
+	for cqlType, expected := range map[string]string{
+		"VARCHAR":                 "text",
+		"map<varchar, int>":       "map<text,int>",
+		"frozen<varchar_address>": "frozen<varchar_address>",
+	} {
+		if normalized := normalizeCQLType(cqlType); normalized != expected {
+			t.Errorf("expected %s for %s, got %s", expected, cqlType, normalized)
+		}
+	}
+}
+
+func TestTableSchemaDifferences(t *testing.T) {
+	columns := []tableColumn{
+		{Name: "id", Type: "uuid"},
+		{Name: "name", Type: "varchar"},
+	}
+	if differences := tableSchemaDifferences(columns, []string{"id"}, nil, []tableColumn{
+		{Name: "id", Type: "uuid"},
+		{Name: "name", Type: "text"},
+	}, []string{"id"}, []string{}); len(differences) > 0 {
+		t.Errorf("expected no differences, got %v", differences)
+	}
+
+	expected := []string{
+		"~ partition_keys: [id], existing [id name]",
+		"~ column id uuid, existing timeuuid",
+		"- column name varchar",
+		"+ column email text",
+	}
+	differences := tableSchemaDifferences(columns, []string{"id"}, nil, []tableColumn{
+		{Name: "id", Type: "timeuuid"},
+		{Name: "email", Type: "text"},
+	}, []string{"id", "name"}, nil)
+	if !reflect.DeepEqual(differences, expected) {
+		t.Errorf("expected %v, got %v", expected, differences)
+	}
+}
+
+func TestCustomPropertiesLiteral(t *testing.T) {
+	literal := customPropertiesLiteral(map[string]map[string]interface{}{
+		"capacity_mode": {
+			"throughput_mode":      "PROVISIONED",
+			"read_capacity_units":  10,
+			"write_capacity_units": 20,
+		},
+	})
+	expected := "{ 'capacity_mode' : { 'read_capacity_units' : 10, 'throughput_mode' : 'PROVISIONED', 'write_capacity_units' : 20 } }"
+	if literal != expected {
+		t.Errorf("expected %s, got %s", expected, literal)
+	}
+}
+
+func TestTagQueries(t *testing.T) {
+	queries := tagQueries("shop", "orders", map[string]interface{}{
+		"team": "payments",
+		"env":  "staging",
+	}, map[string]interface{}{
+		"team":  "payments",
+		"env":   "production",
+		"owner": "alice",
+	})
+	expected := []string{
+		`ALTER TABLE "shop"."orders" ADD TAGS { 'env' : 'production', 'owner' : 'alice' }`,
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected %v, got %v", expected, queries)
+	}
+
+	queries = tagQueries("shop", "orders", map[string]interface{}{"team": "payments"}, map[string]interface{}{})
+	expected = []string{`ALTER TABLE "shop"."orders" DROP TAGS { 'team' : 'payments' }`}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected %v, got %v", expected, queries)
+	}
+}
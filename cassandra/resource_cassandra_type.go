@@ -0,0 +1,253 @@
+package cassandra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gocql/gocql"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCassandraType() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manage user-defined types",
+		CreateContext: resourceTypeCreate,
+		ReadContext:   resourceTypeRead,
+		UpdateContext: resourceTypeUpdate,
+		DeleteContext: resourceTypeDelete,
+		CustomizeDiff: validateTypeFieldChanges,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTypeImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the type",
+				ValidateFunc: validation.StringLenBetween(1, 256),
+			},
+			"keyspace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Keyspace to create the type within, quote it (e.g. \"MyKeyspace\") for case sensitive names",
+			},
+			"field": {
+				Type: schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the field",
+							ValidateFunc: validation.StringLenBetween(1, 256),
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "CQL type of the field, e.g. text, int, list<text> or frozen<address>",
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+					},
+				},
+				Required:    true,
+				MinItems:    1,
+				Description: "Ordered fields of the type. Fields appended to the list are added in place, removing, reordering or changing fields is not supported by Cassandra",
+			},
+			"consistency": resourceConsistencySchema(),
+		},
+	}
+}
+
+// resourceTypeImport accepts IDs of the form keyspace.type.
+func resourceTypeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keyspaceName, name, ok := strings.Cut(d.Id(), ".")
+	if !ok || keyspaceName == "" || name == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected keyspace.type", d.Id())
+	}
+
+	d.SetId(name)
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	return []*schema.ResourceData{d}, nil
+}
+
+// typeFields returns the fields of the type, reusing tableColumn for name and CQL type.
+func typeFields(d *schema.ResourceData) []tableColumn {
+	return expandTypeFields(d.Get("field").([]interface{}))
+}
+
+// appendedTypeFields returns the fields of new appended to old. It fails when
+// fields of old were removed, renamed, reordered or changed type, which Cassandra
+// does not support once the type exists.
+func appendedTypeFields(old []tableColumn, new []tableColumn) ([]tableColumn, error) {
+	var problems []string
+	for i, field := range old {
+		switch {
+		case i >= len(new) || new[i].Name != field.Name:
+			problems = append(problems, fmt.Sprintf("field %s was removed or moved", field.Name))
+		case normalizeCQLType(new[i].Type) != normalizeCQLType(field.Type):
+			problems = append(problems, fmt.Sprintf("field %s changed type from %s to %s", field.Name, field.Type, new[i].Type))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("fields of an existing type can only be appended: %s. Create a new type instead", strings.Join(problems, ", "))
+	}
+	return new[len(old):], nil
+}
+
+func expandTypeFields(rawFields []interface{}) []tableColumn {
+	fields := make([]tableColumn, 0, len(rawFields))
+	for _, rawField := range rawFields {
+		field := rawField.(map[string]interface{})
+		fields = append(fields, tableColumn{
+			Name: field["name"].(string),
+			Type: field["type"].(string),
+		})
+	}
+	return fields
+}
+
+// validateTypeFieldChanges rejects at plan time field changes other than additions.
+func validateTypeFieldChanges(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("field") || !d.NewValueKnown("field") {
+		return nil
+	}
+	old, new := d.GetChange("field")
+	_, err := appendedTypeFields(expandTypeFields(old.([]interface{})), expandTypeFields(new.([]interface{})))
+	return err
+}
+
+func generateCreateTypeQueryString(keyspace string, name string, ifNotExists bool, fields []tableColumn) string {
+	definitions := make([]string, 0, len(fields))
+	for _, field := range fields {
+		definitions = append(definitions, fmt.Sprintf(`%q %s`, field.Name, field.Type))
+	}
+
+	action := "CREATE TYPE"
+	if ifNotExists {
+		action = "CREATE TYPE IF NOT EXISTS"
+	}
+	return fmt.Sprintf(`%s %q.%q (%s)`, action, unquoteIdentifier(keyspace), name, strings.Join(definitions, ", "))
+}
+
+func resourceTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	query := generateCreateTypeQueryString(keyspaceName, name, providerConfig.AdoptExisting, typeFields(d))
+
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Creating type '%s' in '%s'", name, keyspaceName)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+
+	d.SetId(name)
+	diags = append(diags, resourceTypeRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	var fieldNames, fieldTypes []string
+	err := session.Query(`SELECT field_names, field_types FROM system_schema.types WHERE keyspace_name = ? AND type_name = ?`, unquoteIdentifier(keyspaceName), name).WithContext(ctx).Scan(&fieldNames, &fieldTypes)
+	if err == gocql.ErrNotFound {
+		log.Printf("[WARN] Type '%s' no longer exists in '%s', removing it from the state", name, keyspaceName)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return errorDiagnostics(err, "", cty.GetAttrPath("keyspace"))
+	}
+
+	configured := typeFields(d)
+	fields := make([]tableColumn, 0, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		field := tableColumn{Name: fieldName, Type: fieldTypes[i]}
+		// keep the spelling of the configuration for equivalent types
+		if i < len(configured) && configured[i].Name == field.Name && normalizeCQLType(configured[i].Type) == normalizeCQLType(field.Type) {
+			field.Type = configured[i].Type
+		}
+		fields = append(fields, field)
+	}
+
+	d.Set("name", name)
+	d.Set("keyspace", keyspaceName)
+	d.Set("field", flattenTableColumns(fields))
+	return diags
+}
+
+func resourceTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	if d.HasChange("field") {
+		old, new := d.GetChange("field")
+		added, err := appendedTypeFields(expandTypeFields(old.([]interface{})), expandTypeFields(new.([]interface{})))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		providerConfig := meta.(*ProviderConfig)
+		session, sessionCreateError := providerConfig.createSession(d)
+		if sessionCreateError != nil {
+			return errorDiagnostics(sessionCreateError, "", nil)
+		}
+		defer session.Close()
+
+		for _, field := range added {
+			log.Printf("Adding field '%s' to type '%s' in '%s'", field.Name, name, keyspaceName)
+			query := fmt.Sprintf(`ALTER TYPE %q.%q ADD %q %s`, unquoteIdentifier(keyspaceName), name, field.Name, field.Type)
+			if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+				return errorDiagnostics(err, query, nil)
+			}
+		}
+	}
+
+	diags = append(diags, resourceTypeRead(ctx, d, meta)...)
+	return diags
+}
+
+func resourceTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Id()
+	keyspaceName := d.Get("keyspace").(string)
+	var diags diag.Diagnostics
+
+	providerConfig := meta.(*ProviderConfig)
+	session, sessionCreateError := providerConfig.createSession(d)
+	if sessionCreateError != nil {
+		return errorDiagnostics(sessionCreateError, "", nil)
+	}
+	defer session.Close()
+
+	log.Printf("Deleting type '%s' in '%s'", name, keyspaceName)
+	query := fmt.Sprintf(`DROP TYPE IF EXISTS %q.%q`, unquoteIdentifier(keyspaceName), name)
+	if err := providerConfig.executeDDL(ctx, session, query); err != nil {
+		return errorDiagnostics(err, query, nil)
+	}
+	return diags
+}
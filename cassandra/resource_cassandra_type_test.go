@@ -0,0 +1,88 @@
+package cassandra
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestGenerateCreateTypeQueryString(t *testing.T) {
+	query := generateCreateTypeQueryString("shop", "address", false, []tableColumn{
+		{Name: "street", Type: "text"},
+		{Name: "zip", Type: "int"},
+	})
+	expected := `CREATE TYPE "shop"."address" ("street" text, "zip" int)`
+	if query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+}
+
+func TestAccCassandraType_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCassandraTypeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cassandra_type.address", "field.#", "2"),
+					resource.TestCheckResourceAttr("cassandra_type.address", "field.1.name", "zip"),
+				),
+			},
+			{
+				ResourceName:      "cassandra_type.address",
+				ImportStateId:     "type_test.address",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccCassandraTypeConfig = `
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "type_test"
+  replication_strategy = "SimpleStrategy"
+  strategy_options     = {
+    replication_factor = 1
+  }
+}
+
+resource "cassandra_type" "address" {
+  name     = "address"
+  keyspace = cassandra_keyspace.keyspace.name
+
+  field {
+    name = "street"
+    type = "text"
+  }
+
+  field {
+    name = "zip"
+    type = "int"
+  }
+}
+`
+
+func TestAppendedTypeFields(t *testing.T) {
+	old := []tableColumn{
+		{Name: "street", Type: "text"},
+		{Name: "zip", Type: "int"},
+	}
+
+	added, err := appendedTypeFields(old, []tableColumn{
+		{Name: "street", Type: "varchar"},
+		{Name: "zip", Type: "int"},
+		{Name: "city", Type: "text"},
+	})
+	if err != nil || len(added) != 1 || added[0].Name != "city" {
+		t.Errorf("expected city to be added, got %v (%v)", added, err)
+	}
+
+	if _, err := appendedTypeFields(old, []tableColumn{{Name: "street", Type: "text"}}); err == nil {
+		t.Error("expected removing a field to fail")
+	}
+	if _, err := appendedTypeFields(old, []tableColumn{{Name: "street", Type: "text"}, {Name: "zip", Type: "text"}}); err == nil {
+		t.Error("expected changing the type of a field to fail")
+	}
+}
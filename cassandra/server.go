@@ -0,0 +1,13 @@
+package cassandra
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+// ProviderServerFactory returns the protocol version 5 server factory served by main.
+// The SDK v2 provider is exposed through its raw gRPC server, which is what
+// tf5muxserver combines, so plugin framework based providers can later be muxed in
+// front of it here without changing how the binary is served.
+func ProviderServerFactory() func() tfprotov5.ProviderServer {
+	return Provider().GRPCProvider
+}
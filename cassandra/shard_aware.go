@@ -0,0 +1,127 @@
+package cassandra
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/gocql/gocql"
+)
+
+const (
+	shardAwareMinLocalPort = 49152
+	shardAwareMaxLocalPort = 65535
+	// shardAwareBindAttempts bounds the local ports tried for a shard before dialing the native port
+	shardAwareBindAttempts = 8
+)
+
+// shardAwareDialer connects to the shard-aware port of Scylla nodes. Scylla hands a
+// connection made to that port to the shard source_port % SCYLLA_NR_SHARDS, so the
+// local port is chosen to spread the connections of a node over its shards in turn
+// instead of leaving every session on the shard the kernel happens to pick. Nodes
+// that do not list a shard-aware port in their SUPPORTED response, Cassandra nodes
+// included, and nodes whose shard-aware port is unreachable are dialed on the
+// native port.
+type shardAwareDialer struct {
+	port   int
+	dialer *net.Dialer
+	probe  *gocql.ClusterConfig
+
+	mu        sync.Mutex
+	shards    map[string]int
+	nextShard map[string]int
+}
+
+func newShardAwareDialer(cluster *gocql.ClusterConfig, port int) *shardAwareDialer {
+	// the probe dials the native port directly, cluster.Dialer is about to be this dialer
+	probe := *cluster
+	probe.Dialer = nil
+	return &shardAwareDialer{
+		port:      port,
+		dialer:    &net.Dialer{Timeout: cluster.ConnectTimeout, KeepAlive: cluster.SocketKeepalive},
+		probe:     &probe,
+		shards:    make(map[string]int),
+		nextShard: make(map[string]int),
+	}
+}
+
+func (s *shardAwareDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return s.dialer.DialContext(ctx, network, addr)
+	}
+	shard, nrShards := s.shard(ctx, addr)
+	if nrShards == 0 {
+		return s.dialer.DialContext(ctx, network, addr)
+	}
+
+	shardAwareAddr := net.JoinHostPort(host, strconv.Itoa(s.port))
+	start := shardAwareMinLocalPort + rand.Intn(shardAwareMaxLocalPort-shardAwareMinLocalPort+1)
+	for attempt := 0; attempt < shardAwareBindAttempts; attempt++ {
+		dialer := *s.dialer
+		dialer.LocalAddr = &net.TCPAddr{Port: shardAwareLocalPort(start+attempt*nrShards, shard, nrShards)}
+		conn, err := dialer.DialContext(ctx, network, shardAwareAddr)
+		if err == nil {
+			return conn, nil
+		}
+		if !errors.Is(err, syscall.EADDRINUSE) && !errors.Is(err, syscall.EADDRNOTAVAIL) {
+			log.Printf("[WARN] Unable to connect to the shard-aware port of %s, using %s: %s", host, addr, err)
+			s.mu.Lock()
+			s.shards[addr] = 0
+			s.mu.Unlock()
+			break
+		}
+	}
+	return s.dialer.DialContext(ctx, network, addr)
+}
+
+// shard returns the next shard to connect to on the node at addr and the number of
+// shards of the node, zero when the node has no shard-aware port.
+func (s *shardAwareDialer) shard(ctx context.Context, addr string) (int, int) {
+	s.mu.Lock()
+	nrShards, probed := s.shards[addr]
+	s.mu.Unlock()
+	if !probed {
+		supported, err := requestSupportedOptions(ctx, s.probe, addr)
+		if err != nil {
+			// not remembered, the native dial reports the error and the next dial probes again
+			return 0, 0
+		}
+		nrShards = 0
+		if _, ok := supported["SCYLLA_SHARD_AWARE_PORT"]; ok && len(supported["SCYLLA_NR_SHARDS"]) > 0 {
+			nrShards, _ = strconv.Atoi(supported["SCYLLA_NR_SHARDS"][0])
+		}
+		if nrShards > 0 {
+			log.Printf("Spreading the connections to %s over its %d shards through port %d", addr, nrShards, s.port)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !probed {
+		s.shards[addr] = nrShards
+	}
+	if nrShards <= 0 {
+		return 0, 0
+	}
+	shard := s.nextShard[addr] % nrShards
+	s.nextShard[addr] = shard + 1
+	return shard, nrShards
+}
+
+// shardAwareLocalPort returns the first local port from start that Scylla maps to
+// shard, wrapping around at the end of the ephemeral port range.
+func shardAwareLocalPort(start int, shard int, nrShards int) int {
+	span := shardAwareMaxLocalPort - shardAwareMinLocalPort + 1
+	port := shardAwareMinLocalPort + (start-shardAwareMinLocalPort)%span
+	port += ((shard-port)%nrShards + nrShards) % nrShards
+	if port > shardAwareMaxLocalPort {
+		port = shardAwareMinLocalPort + ((shard-shardAwareMinLocalPort)%nrShards+nrShards)%nrShards
+	}
+	return port
+}
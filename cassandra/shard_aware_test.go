@@ -0,0 +1,138 @@
+package cassandra
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+func TestShardAwareLocalPort(t *testing.T) {
+	cases := []struct {
+		start    int
+		shard    int
+		nrShards int
+		expected int
+	}{
+		{49152, 0, 4, 49152},
+		{49152, 3, 4, 49155},
+		{49153, 0, 4, 49156},
+		{60001, 2, 7, 60006},
+		// past the end of the range the first matching port of the range is used
+		{65535, 0, 4, 49152},
+		{65535, 3, 4, 65535},
+		{65536, 1, 4, 49153},
+	}
+	for _, c := range cases {
+		port := shardAwareLocalPort(c.start, c.shard, c.nrShards)
+		if port != c.expected {
+			t.Errorf("shardAwareLocalPort(%d, %d, %d) = %d, expected %d", c.start, c.shard, c.nrShards, port, c.expected)
+		}
+		if port%c.nrShards != c.shard {
+			t.Errorf("port %d does not map to shard %d of %d", port, c.shard, c.nrShards)
+		}
+	}
+}
+
+func TestShardAwareDialer(t *testing.T) {
+	shardAware, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shardAware.Close()
+	_, shardAwarePort, _ := net.SplitHostPort(shardAware.Addr().String())
+
+	native := serveSupportedOptions(t, map[string]string{
+		"SCYLLA_NR_SHARDS":        "4",
+		"SCYLLA_SHARD_AWARE_PORT": shardAwarePort,
+	})
+	defer native.Close()
+
+	cluster := gocql.NewCluster()
+	cluster.ConnectTimeout = time.Second
+	port, _ := strconv.Atoi(shardAwarePort)
+	dialer := newShardAwareDialer(cluster, port)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for shard := 0; shard < 6; shard++ {
+		conn, err := dialer.DialContext(ctx, "tcp", native.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		accepted, err := shardAware.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		remotePort := accepted.RemoteAddr().(*net.TCPAddr).Port
+		if remotePort%4 != shard%4 {
+			t.Errorf("connection %d landed on shard %d", shard, remotePort%4)
+		}
+		accepted.Close()
+		conn.Close()
+	}
+}
+
+func TestShardAwareDialer_cassandra(t *testing.T) {
+	native := serveSupportedOptions(t, map[string]string{"CQL_VERSION": "3.4.5"})
+	defer native.Close()
+
+	cluster := gocql.NewCluster()
+	cluster.ConnectTimeout = time.Second
+	dialer := newShardAwareDialer(cluster, 19042)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", native.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if nrShards := dialer.shards[native.Addr().String()]; nrShards != 0 {
+		t.Errorf("expected no shards, got %d", nrShards)
+	}
+}
+
+// serveSupportedOptions answers the first OPTIONS request with options and accepts
+// the connections that follow.
+func serveSupportedOptions(t *testing.T, options map[string]string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		request := make([]byte, 9)
+		if _, err := io.ReadFull(conn, request); err != nil || request[4] != cqlOpcodeOptions {
+			conn.Close()
+			return
+		}
+		body := binary.BigEndian.AppendUint16(nil, uint16(len(options)))
+		for key, value := range options {
+			body = appendCQLString(body, key)
+			body = append(body, 0, 1)
+			body = appendCQLString(body, value)
+		}
+		response := []byte{0x84, 0, 0, 0, cqlOpcodeSupported, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(response[5:], uint32(len(body)))
+		conn.Write(append(response, body...))
+		conn.Close()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return listener
+}
@@ -3,9 +3,13 @@ package cassandra
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"hash/crc32"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func hash(s string) string {
@@ -36,3 +40,95 @@ func setToArray(s interface{}) []string {
 	}
 	return ret
 }
+
+func listToArray(l interface{}) []string {
+	list, ok := l.([]interface{})
+	if !ok {
+		return []string{}
+	}
+
+	ret := []string{}
+	for _, elem := range list {
+		ret = append(ret, elem.(string))
+	}
+	return ret
+}
+
+// unquoteIdentifier returns the name Cassandra stores for a CQL identifier. Quoted
+// identifiers keep their case, unquoted ones are case insensitive and stored lowercased.
+func unquoteIdentifier(identifier string) string {
+	if len(identifier) >= 2 && strings.HasPrefix(identifier, `"`) && strings.HasSuffix(identifier, `"`) {
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], `""`, `"`)
+	}
+	return strings.ToLower(identifier)
+}
+
+// quoteLiteral quotes s as a CQL string literal, e.g. for role names.
+func quoteLiteral(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// resourceConsistencySchema is the per-resource override of the provider consistency level.
+func resourceConsistencySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  fmt.Sprintf("Consistency level used for the queries issued for this resource, overrides the provider default. One of %s", strings.Join(allowedConsistencyNames(), ", ")),
+		ValidateFunc: validation.StringInSlice(allowedConsistencyNames(), false),
+	}
+}
+
+// readFileOrContent returns content when set, otherwise the content of the file at path.
+func readFileOrContent(content string, path string) (string, error) {
+	if content != "" || path == "" {
+		return content, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// identityAttributeSchema is a string attribute of a resource identity, the
+// structured address import blocks may use instead of an import ID.
+func identityAttributeSchema(required bool, description string) *schema.Schema {
+	return &schema.Schema{
+		Type:              schema.TypeString,
+		RequiredForImport: required,
+		OptionalForImport: !required,
+		Description:       description,
+	}
+}
+
+// setIdentity records the identity of the resource from its identity attributes.
+func setIdentity(d *schema.ResourceData, values map[string]string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		if err := identity.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// identityValues returns the identity attributes given in an import block, none
+// when the resource is imported by ID.
+func identityValues(d *schema.ResourceData, keys ...string) (map[string]string, error) {
+	if d.Id() != "" {
+		return nil, nil
+	}
+	identity, err := d.Identity()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, _ := identity.Get(key).(string)
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,25 @@
+package cassandra
+
+import (
+	"testing"
+)
+
+func TestUnquoteIdentifier(t *testing.T) {
+	cases := map[string]string{
+		`some_keyspace`:    `some_keyspace`,
+		`SomeKeyspace`:     `somekeyspace`,
+		`"SomeKeyspace"`:   `SomeKeyspace`,
+		`"Some""Keyspace"`: `Some"Keyspace`,
+	}
+	for identifier, expected := range cases {
+		if actual := unquoteIdentifier(identifier); actual != expected {
+			t.Errorf("unquoteIdentifier(%q) = %q, expected %q", identifier, actual, expected)
+		}
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	if quoted := quoteLiteral("o'brien"); quoted != "'o''brien'" {
+		t.Errorf("expected 'o''brien', got %s", quoted)
+	}
+}
@@ -0,0 +1,153 @@
+package cassandra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// sourcedCredentials holds connection secrets fetched from an external secret
+// store at configure time. Empty fields leave the provider settings untouched.
+type sourcedCredentials struct {
+	Username   string
+	Password   string
+	RootCA     string
+	ClientCert string
+	ClientKey  string
+}
+
+// vaultClient is a minimal client for the Vault HTTP API, covering token and
+// AppRole authentication and reads from KV version 1 and 2 secret engines.
+type vaultClient struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+type vaultResponse struct {
+	Data map[string]interface{} `json:"data"`
+	Auth *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+func (c *vaultClient) do(ctx context.Context, method string, path string, body interface{}) (*vaultResponse, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(c.address, "/"), strings.TrimPrefix(path, "/"))
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var vaultResp vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&vaultResp); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to decode vault response from %s: %w", path, err)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("vault returned %d for %s: %s", resp.StatusCode, path, strings.Join(vaultResp.Errors, ", "))
+	}
+	return &vaultResp, nil
+}
+
+func (c *vaultClient) loginAppRole(ctx context.Context, mount string, roleID string, secretID string) error {
+	resp, err := c.do(ctx, http.MethodPost, fmt.Sprintf("auth/%s/login", mount), map[string]string{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return fmt.Errorf("vault approle login did not return a token")
+	}
+	c.token = resp.Auth.ClientToken
+	return nil
+}
+
+// readSecret returns the string values stored at path, unwrapping KV version 2 responses.
+func (c *vaultClient) readSecret(ctx context.Context, path string) (map[string]string, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := resp.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	secret := make(map[string]string, len(data))
+	for key, value := range data {
+		if str, ok := value.(string); ok {
+			secret[key] = str
+		}
+	}
+	return secret, nil
+}
+
+// readVaultCredentials fetches the provider credentials described by the vault block.
+func readVaultCredentials(ctx context.Context, config map[string]interface{}, timeout time.Duration) (*sourcedCredentials, error) {
+	client := &vaultClient{
+		address:    config["address"].(string),
+		token:      config["token"].(string),
+		namespace:  config["namespace"].(string),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+	if client.address == "" {
+		return nil, fmt.Errorf("vault address must be set, either in the vault block or through VAULT_ADDR")
+	}
+
+	if roleID := config["role_id"].(string); roleID != "" {
+		if err := client.loginAppRole(ctx, config["approle_mount"].(string), roleID, config["secret_id"].(string)); err != nil {
+			return nil, err
+		}
+	}
+	if client.token == "" {
+		return nil, fmt.Errorf("vault requires a token or approle credentials")
+	}
+
+	path := config["path"].(string)
+	log.Printf("Reading provider credentials from vault path %s", path)
+	secret, err := client.readSecret(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sourcedCredentials{
+		Username:   secret[config["username_key"].(string)],
+		Password:   secret[config["password_key"].(string)],
+		RootCA:     secret[config["root_ca_key"].(string)],
+		ClientCert: secret[config["client_cert_key"].(string)],
+		ClientKey:  secret[config["client_key_key"].(string)],
+	}, nil
+}
@@ -0,0 +1,49 @@
+package cassandra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadVaultCredentials_appRoleKV2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/approle/login":
+			w.Write([]byte(`{"auth": {"client_token": "s.approle"}}`))
+		case "/v1/secret/data/cassandra":
+			if r.Header.Get("X-Vault-Token") != "s.approle" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors": ["permission denied"]}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"data": {"user": "admin", "password": "secret"}, "metadata": {"version": 1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	credentials, err := readVaultCredentials(context.Background(), map[string]interface{}{
+		"address":         server.URL,
+		"token":           "",
+		"namespace":       "",
+		"role_id":         "role",
+		"secret_id":       "secret",
+		"approle_mount":   "approle",
+		"path":            "secret/data/cassandra",
+		"username_key":    "user",
+		"password_key":    "password",
+		"root_ca_key":     "",
+		"client_cert_key": "",
+		"client_key_key":  "",
+	}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if credentials.Username != "admin" || credentials.Password != "secret" {
+		t.Fatalf("unexpected credentials %+v", credentials)
+	}
+}
@@ -0,0 +1,7 @@
+data "cassandra_clients" "app" {
+  username = "app"
+}
+
+output "app_connections" {
+  value = data.cassandra_clients.app.client_count
+}
@@ -0,0 +1,5 @@
+data "cassandra_cluster_info" "cluster" {}
+
+output "release_version" {
+  value = data.cassandra_cluster_info.cluster.release_version
+}
@@ -0,0 +1,7 @@
+data "cassandra_keyspace" "keyspace" {
+  name = "some_keyspace_name"
+}
+
+output "replication" {
+  value = data.cassandra_keyspace.keyspace.strategy_options
+}
@@ -0,0 +1,5 @@
+data "cassandra_nodes" "nodes" {}
+
+output "nodes_down" {
+  value = [for node in data.cassandra_nodes.nodes.nodes : node.address if !node.up]
+}
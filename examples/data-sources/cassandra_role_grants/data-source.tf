@@ -0,0 +1,7 @@
+data "cassandra_role_grants" "app" {
+  role = "app"
+}
+
+output "app_tables" {
+  value = [for permission in data.cassandra_role_grants.app.permissions : "${permission.keyspace_name}.${permission.identifier}" if permission.resource_type == "table"]
+}
@@ -0,0 +1,18 @@
+data "cassandra_settings" "settings" {
+  names = ["authenticator"]
+}
+
+resource "cassandra_keyspace" "keyspace" {
+  name                 = "some_keyspace_name"
+  replication_strategy = "SimpleStrategy"
+  strategy_options = {
+    replication_factor = 1
+  }
+
+  lifecycle {
+    precondition {
+      condition     = data.cassandra_settings.settings.settings.authenticator != "AllowAllAuthenticator"
+      error_message = "The cluster must require authentication."
+    }
+  }
+}
@@ -0,0 +1,8 @@
+resource "cassandra_aggregate" "total" {
+  name              = "total"
+  keyspace          = "my_keyspace"
+  argument_types    = ["int"]
+  state_function    = cassandra_function.plus.name
+  state_type        = "int"
+  initial_condition = "0"
+}
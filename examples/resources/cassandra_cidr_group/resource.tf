@@ -0,0 +1,4 @@
+resource "cassandra_cidr_group" "office" {
+  name  = "office"
+  cidrs = ["10.0.0.0/8", "192.168.0.0/16"]
+}
@@ -0,0 +1,7 @@
+resource "cassandra_column_mask" "email" {
+  keyspace  = "shop"
+  table     = "users"
+  column    = "email"
+  function  = "mask_inner"
+  arguments = ["1", "null"]
+}
@@ -0,0 +1,8 @@
+resource "cassandra_cql_script" "events_by_kind" {
+  create_statements = [
+    "CREATE MATERIALIZED VIEW IF NOT EXISTS my_keyspace.events_by_kind AS SELECT * FROM my_keyspace.events WHERE kind IS NOT NULL AND id IS NOT NULL PRIMARY KEY (kind, id)",
+  ]
+  destroy_statements = [
+    "DROP MATERIALIZED VIEW IF EXISTS my_keyspace.events_by_kind",
+  ]
+}
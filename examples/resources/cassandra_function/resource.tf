@@ -0,0 +1,17 @@
+resource "cassandra_function" "plus" {
+  name        = "plus"
+  keyspace    = "my_keyspace"
+  return_type = "int"
+  language    = "java"
+  body        = "return a + b;"
+
+  argument {
+    name = "a"
+    type = "int"
+  }
+
+  argument {
+    name = "b"
+    type = "int"
+  }
+}
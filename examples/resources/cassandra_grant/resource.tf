@@ -4,3 +4,17 @@ resource "cassandra_grant" "all_access_to_keyspace" {
   keyspace_name = "test"
   grantee       = "migration"
 }
+
+# alias of keyspace, the permissions on a keyspace apply to all of its tables
+resource "cassandra_grant" "read_all_tables" {
+  privilege     = "select"
+  resource_type = "all tables in keyspace"
+  keyspace_name = "test"
+  grantee       = "reporting"
+}
+
+resource "cassandra_grant" "read_everything" {
+  privilege     = "select"
+  resource_type = "all keyspaces"
+  grantee       = "auditor"
+}
@@ -0,0 +1,4 @@
+resource "cassandra_identity" "app" {
+  identity = "spiffe://example.com/app"
+  role     = cassandra_role.app.name
+}
@@ -0,0 +1,25 @@
+resource "cassandra_index" "users_by_email" {
+  name     = "users_by_email"
+  keyspace = "my_keyspace"
+  table    = "users"
+  column   = "email"
+}
+
+resource "cassandra_index" "users_by_tag" {
+  name     = "users_by_tag"
+  keyspace = "my_keyspace"
+  table    = "users"
+  column   = "tags"
+  target   = "keys"
+}
+
+resource "cassandra_index" "users_by_name" {
+  name     = "users_by_name"
+  keyspace = "my_keyspace"
+  table    = "users"
+  column   = "name"
+  class    = "StorageAttachedIndex"
+  options = {
+    case_sensitive = "false"
+  }
+}
@@ -0,0 +1,4 @@
+resource "cassandra_role_cidr_access" "app" {
+  role        = cassandra_role.app.name
+  cidr_groups = [cassandra_cidr_group.office.name]
+}
@@ -0,0 +1,4 @@
+resource "cassandra_role_grant" "app_reads" {
+  role    = cassandra_role.reader.name
+  grantee = cassandra_role.app.name
+}
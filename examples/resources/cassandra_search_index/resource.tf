@@ -0,0 +1,11 @@
+resource "cassandra_search_index" "orders" {
+  keyspace = "my_keyspace"
+  table    = "orders"
+  columns  = ["customer", "status"]
+  profiles = ["spaceSavingAll"]
+
+  config = {
+    realtime       = "true"
+    autoCommitTime = "1000"
+  }
+}
@@ -0,0 +1,8 @@
+resource "cassandra_system_auth_replication" "system_auth" {
+  keyspace             = "system_auth"
+  replication_strategy = "NetworkTopologyStrategy"
+  strategy_options = {
+    dc1 = 3
+    dc2 = 3
+  }
+}
@@ -1,15 +1,32 @@
 resource "cassandra_table" "table" {
-  name     = "my_table"
-  keyspace = "my-keyspace"
-  row_keys = ["Name"]
+  name            = "my_table"
+  keyspace        = "my_keyspace"
+  partition_keys  = ["tenant", "name"]
+  clustering_keys = ["email"]
 
-  attribute {
+  column {
+    name = "tenant"
+    type = "text"
+  }
+
+  column {
     name = "name"
-    type = "S"
+    type = "text"
   }
 
-  attribute {
+  column {
     name = "email"
-    type = "S"
+    type = "text"
+  }
+
+  column {
+    name = "tags"
+    type = "set<text>"
+  }
+
+  compaction = {
+    class                  = "TimeWindowCompactionStrategy"
+    compaction_window_unit = "DAYS"
+    compaction_window_size = 1
   }
 }
@@ -0,0 +1,8 @@
+resource "cassandra_table_rows" "countries" {
+  keyspace = "my_keyspace"
+  table    = "countries"
+  rows = [
+    jsonencode({ code = "fr", name = "France" }),
+    jsonencode({ code = "de", name = "Germany" }),
+  ]
+}
@@ -0,0 +1,14 @@
+resource "cassandra_type" "address" {
+  name     = "address"
+  keyspace = "my_keyspace"
+
+  field {
+    name = "street"
+    type = "text"
+  }
+
+  field {
+    name = "zip"
+    type = "int"
+  }
+}
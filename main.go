@@ -1,13 +1,11 @@
 package main
 
 import (
-	"context"
 	"flag"
 	"log"
 
 	"github.com/dactily/terraform-provider-cassandra/cassandra"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 func main() {
@@ -15,21 +13,13 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return cassandra.Provider()
-		},
-	}
-
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/dactily/cassandra", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
-
-	plugin.Serve(&plugin.ServeOpts{ProviderFunc: cassandra.Provider})
+	err := tf5server.Serve("registry.terraform.io/dactily/cassandra", cassandra.ProviderServerFactory(), serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }